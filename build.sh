@@ -26,7 +26,7 @@ echo "Running go fmt..."
 go fmt ./...
 
 echo "Running tests..."
-go test -v -covermode=count -coverprofile="profile.cov"
+go test -v -race -covermode=atomic -coverprofile="profile.cov"
 go tool cover -func profile.cov
 
 echo "Building..."
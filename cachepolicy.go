@@ -0,0 +1,190 @@
+package goff
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// Cache Policies
+//
+
+// NoExpiration is a TTL for content that never changes, such as content from
+// a finished league, which is kept in a cache until it is evicted.
+const NoExpiration time.Duration = -1
+
+// weekParamPattern matches the week parameter of an API URL, for example
+// ";week=3" in ".../league/223.l.431/scoreboard;week=3" or ";week=1,2,3" when
+// several weeks are requested.
+var weekParamPattern = regexp.MustCompile(`;week=([0-9,]+)`)
+
+// CachePolicy decides how long content retrieved from the fantasy sports API
+// can be cached.
+type CachePolicy interface {
+	// TTL returns how long the content retrieved for the URL is valid. Zero
+	// uses the default duration of the cache, and NoExpiration means the
+	// content never changes.
+	TTL(url string, content *FantasyContent) time.Duration
+}
+
+// TTLCache is a Cache that can keep each piece of content for a different
+// amount of time.
+type TTLCache interface {
+	Cache
+
+	// Sets the content retrieved for the URL at the given time that is valid
+	// for the given TTL
+	SetWithTTL(url string, time time.Time, content *FantasyContent, ttl time.Duration)
+}
+
+// PolicyCache implements Cache by storing content in a TTLCache for as long
+// as its CachePolicy allows.
+type PolicyCache struct {
+	Cache  TTLCache
+	Policy CachePolicy
+}
+
+// NewPolicyCache creates a Cache that stores content in the given cache for
+// the TTL chosen by the given policy, for example:
+//
+//    cache := goff.NewPolicyCache(
+//        goff.NewLRUCache(clientID, time.Hour, lruCache),
+//        goff.DefaultCachePolicy())
+//    client := goff.NewCachedClient(cache, httpClient)
+//
+// See ResourceCachePolicy
+func NewPolicyCache(cache TTLCache, policy CachePolicy) *PolicyCache {
+	return &PolicyCache{
+		Cache:  cache,
+		Policy: policy,
+	}
+}
+
+// Set stores the content retrieved for the URL at the given time using the
+// TTL chosen by the policy.
+func (p *PolicyCache) Set(url string, time time.Time, content *FantasyContent) {
+	p.Cache.SetWithTTL(url, time, content, p.Policy.TTL(url, content))
+}
+
+// Get the content for the URL at the given time.
+func (p *PolicyCache) Get(url string, time time.Time) (content *FantasyContent, ok bool) {
+	return p.Cache.Get(url, time)
+}
+
+// ResourceCachePolicy implements CachePolicy by choosing a TTL from the type
+// of resource requested, the week requested, and the state of the league.
+//
+// Content never expires when it is from a finished league, or from a finished
+// game requested by its numeric key rather than a code such as "nfl" that
+// refers to a new game each season. Otherwise, scoreboards and matchups with
+// games in progress use Live. Content from weeks that were explicitly
+// requested and have all ended never expires, and other content uses the TTL
+// configured for its resource type.
+type ResourceCachePolicy struct {
+	// TTL for scoreboards and matchups with games in progress, or zero for
+	// the default duration of the cache
+	Live time.Duration
+
+	// TTLs keyed by the type of resource requested, as counted by
+	// RequestCountByResource, for example "game" or "league". Resources
+	// without a TTL use the default duration of the cache.
+	Resources map[string]time.Duration
+}
+
+// DefaultCachePolicy returns a ResourceCachePolicy that caches content from
+// finished leagues and weeks indefinitely and scoreboards with games in
+// progress for 30 seconds.
+func DefaultCachePolicy() ResourceCachePolicy {
+	return ResourceCachePolicy{
+		Live: 30 * time.Second,
+	}
+}
+
+// TTL returns how long the content retrieved for the URL is valid.
+func (r ResourceCachePolicy) TTL(url string, content *FantasyContent) time.Duration {
+	if content != nil {
+		if content.League.IsFinished ||
+			(content.Game.IsGameOver && requestsGame(url, content.Game.GameKey)) {
+			return NoExpiration
+		}
+
+		var matchups []Matchup
+		matchups = append(matchups, content.League.Scoreboard.Matchups...)
+		matchups = append(matchups, content.Team.Matchups...)
+		// Games in progress always change, whichever weeks were requested
+		if anyInProgress(matchups) {
+			if r.Live != 0 {
+				return r.Live
+			}
+			return r.Resources[resourceType(url)]
+		}
+		if week, ok := lastRequestedWeek(url); ok &&
+			(week < content.League.CurrentWeek || allFinished(matchups)) {
+			return NoExpiration
+		}
+	}
+	return r.Resources[resourceType(url)]
+}
+
+// requestsGame returns whether the URL names the game with the given numeric
+// key, as opposed to a game code that refers to the current game.
+func requestsGame(url string, gameKey string) bool {
+	if _, err := strconv.ParseUint(gameKey, 10, 64); err != nil {
+		return false
+	}
+	path := "/game/" + gameKey
+	i := strings.Index(url, path)
+	if i < 0 {
+		return false
+	}
+	rest := url[i+len(path):]
+	return rest == "" || strings.ContainsAny(rest[:1], "/;?")
+}
+
+// lastRequestedWeek returns the highest week given as a parameter of the URL,
+// if any.
+func lastRequestedWeek(url string) (int, bool) {
+	if i := strings.Index(url, "?"); i >= 0 {
+		url = url[:i]
+	}
+	match := weekParamPattern.FindStringSubmatch(url)
+	if match == nil {
+		return 0, false
+	}
+
+	last := 0
+	for _, value := range strings.Split(match[1], ",") {
+		week, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, false
+		}
+		if week > last {
+			last = week
+		}
+	}
+	return last, true
+}
+
+// allFinished returns whether there is at least one matchup and all of them
+// are finished.
+func allFinished(matchups []Matchup) bool {
+	for _, matchup := range matchups {
+		if !matchup.IsFinished() {
+			return false
+		}
+	}
+	return len(matchups) > 0
+}
+
+// anyInProgress returns whether games are being played in any of the
+// matchups.
+func anyInProgress(matchups []Matchup) bool {
+	for _, matchup := range matchups {
+		if matchup.Status == MatchupMidEvent {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+package goff
+
+import (
+	"testing"
+	"time"
+
+	lru "vitess.io/vitess/go/cache"
+)
+
+//
+// Test ResourceCachePolicy
+//
+
+func TestResourceCachePolicyTTL(t *testing.T) {
+	policy := ResourceCachePolicy{
+		Live: 30 * time.Second,
+		Resources: map[string]time.Duration{
+			"game": 24 * time.Hour,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		content  *FantasyContent
+		expected time.Duration
+	}{
+		{
+			name:     "no content",
+			url:      "http://example.com/league/223.l.431",
+			content:  nil,
+			expected: 0,
+		},
+		{
+			name:     "league in progress",
+			url:      "http://example.com/league/223.l.431",
+			content:  &FantasyContent{League: League{CurrentWeek: 5}},
+			expected: 0,
+		},
+		{
+			name:     "finished league",
+			url:      "http://example.com/league/223.l.431/standings",
+			content:  &FantasyContent{League: League{IsFinished: true}},
+			expected: NoExpiration,
+		},
+		{
+			name:     "finished game",
+			url:      "http://example.com/game/223/stat_categories",
+			content:  &FantasyContent{Game: Game{GameKey: "223", IsGameOver: true}},
+			expected: NoExpiration,
+		},
+		{
+			name:     "finished game requested by code",
+			url:      "http://example.com/game/nfl/stat_categories",
+			content:  &FantasyContent{Game: Game{GameKey: "314", IsGameOver: true}},
+			expected: 24 * time.Hour,
+		},
+		{
+			name:     "finished game requested by other key",
+			url:      "http://example.com/game/2231",
+			content:  &FantasyContent{Game: Game{GameKey: "223", IsGameOver: true}},
+			expected: 24 * time.Hour,
+		},
+		{
+			name:     "resource TTL",
+			url:      "http://example.com/game/nfl",
+			content:  &FantasyContent{Game: Game{GameKey: "nfl"}},
+			expected: 24 * time.Hour,
+		},
+		{
+			name:     "past week",
+			url:      "http://example.com/league/223.l.431/scoreboard;week=3",
+			content:  &FantasyContent{League: League{CurrentWeek: 5}},
+			expected: NoExpiration,
+		},
+		{
+			name:     "current week",
+			url:      "http://example.com/league/223.l.431/scoreboard;week=5",
+			content:  &FantasyContent{League: League{CurrentWeek: 5}},
+			expected: 0,
+		},
+		{
+			name: "live scoreboard",
+			url:  "http://example.com/league/223.l.431/scoreboard",
+			content: scoreboardContent(
+				MatchupPostEvent,
+				MatchupMidEvent),
+			expected: 30 * time.Second,
+		},
+		{
+			name: "scoreboard before games",
+			url:  "http://example.com/league/223.l.431/scoreboard",
+			content: scoreboardContent(
+				MatchupPreEvent,
+				MatchupPreEvent),
+			expected: 0,
+		},
+		{
+			name: "finished scoreboard for current week",
+			url:  "http://example.com/league/223.l.431/scoreboard",
+			content: scoreboardContent(
+				MatchupPostEvent,
+				MatchupPostEvent),
+			expected: 0,
+		},
+		{
+			name: "finished scoreboard for requested week",
+			url:  "http://example.com/league/223.l.431/scoreboard;week=5?format=json",
+			content: scoreboardContent(
+				MatchupPostEvent,
+				MatchupPostEvent),
+			expected: NoExpiration,
+		},
+		{
+			name:     "range of past weeks",
+			url:      "http://example.com/league/223.l.431/scoreboard;week=1,2,3",
+			content:  scoreboardContent(MatchupPostEvent, MatchupPostEvent),
+			expected: NoExpiration,
+		},
+		{
+			name: "range of weeks including current week",
+			url:  "http://example.com/league/223.l.431/scoreboard;week=1,2,3,4,5",
+			content: scoreboardContent(
+				MatchupPostEvent,
+				MatchupPreEvent),
+			expected: 0,
+		},
+		{
+			name: "range of weeks with live matchup",
+			url:  "http://example.com/league/223.l.431/scoreboard;week=1,2,3,4,5",
+			content: scoreboardContent(
+				MatchupPostEvent,
+				MatchupMidEvent),
+			expected: 30 * time.Second,
+		},
+		{
+			name: "past week with live matchup",
+			url:  "http://example.com/league/223.l.431/scoreboard;week=3",
+			content: scoreboardContent(
+				MatchupMidEvent),
+			expected: 30 * time.Second,
+		},
+		{
+			name: "live team matchups",
+			url:  "http://example.com/team/223.l.431.t.1/matchups;weeks=5",
+			content: &FantasyContent{
+				Team: Team{
+					Matchups: []Matchup{Matchup{Status: MatchupMidEvent}},
+				},
+			},
+			expected: 30 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		actual := policy.TTL(test.url, test.content)
+		if actual != test.expected {
+			t.Fatalf("Unexpected TTL for %s\n\texpected: %s\n\tactual: %s",
+				test.name,
+				test.expected,
+				actual)
+		}
+	}
+}
+
+func TestResourceCachePolicyLiveWithoutTTL(t *testing.T) {
+	policy := ResourceCachePolicy{}
+
+	ttl := policy.TTL(
+		"http://example.com/league/223.l.431/scoreboard;week=1,2,3",
+		scoreboardContent(MatchupMidEvent))
+	if ttl != 0 {
+		t.Fatalf("Unexpected TTL for live scoreboard\n\texpected: %s\n\t"+
+			"actual: %s",
+			time.Duration(0),
+			ttl)
+	}
+}
+
+func TestDefaultCachePolicy(t *testing.T) {
+	policy := DefaultCachePolicy()
+
+	ttl := policy.TTL(
+		"http://example.com/league/223.l.431/scoreboard",
+		scoreboardContent(MatchupMidEvent))
+	if ttl != 30*time.Second {
+		t.Fatalf("Unexpected TTL for live scoreboard\n\texpected: %s\n\t"+
+			"actual: %s",
+			30*time.Second,
+			ttl)
+	}
+
+	ttl = policy.TTL("http://example.com/league/223.l.431", &FantasyContent{})
+	if ttl != 0 {
+		t.Fatalf("Unexpected TTL for league\n\texpected: %s\n\tactual: %s",
+			time.Duration(0),
+			ttl)
+	}
+}
+
+//
+// Test PolicyCache
+//
+
+func TestPolicyCache(t *testing.T) {
+	duration := time.Hour
+	lruCache := lru.NewLRUCache(10, func(_ any) int64 {
+		return 1
+	})
+	cache := NewPolicyCache(
+		NewLRUCache("clientID", duration, lruCache),
+		DefaultCachePolicy())
+
+	setTime := time.Unix(1408281677, 0)
+	liveURL := "http://example.com/league/223.l.431/scoreboard"
+	liveContent := scoreboardContent(MatchupMidEvent)
+	finishedURL := "http://example.com/league/223.l.1"
+	finishedContent := &FantasyContent{League: League{IsFinished: true}}
+	leagueURL := "http://example.com/league/223.l.431"
+	leagueContent := &FantasyContent{League: League{CurrentWeek: 5}}
+	cache.Set(liveURL, setTime, liveContent)
+	cache.Set(finishedURL, setTime, finishedContent)
+	cache.Set(leagueURL, setTime, leagueContent)
+
+	tests := []struct {
+		url      string
+		elapsed  time.Duration
+		expected *FantasyContent
+	}{
+		{url: liveURL, elapsed: 29 * time.Second, expected: liveContent},
+		{url: liveURL, elapsed: 30 * time.Second, expected: nil},
+		{url: leagueURL, elapsed: duration - time.Second, expected: leagueContent},
+		{url: leagueURL, elapsed: duration, expected: nil},
+		{url: finishedURL, elapsed: 365 * 24 * time.Hour, expected: finishedContent},
+	}
+
+	for _, test := range tests {
+		content, ok := cache.Get(test.url, setTime.Add(test.elapsed))
+		if ok != (test.expected != nil) || content != test.expected {
+			t.Fatalf("Unexpected content for %s %s after it was set\n\t"+
+				"expected: %+v\n\tactual: %+v",
+				test.url,
+				test.elapsed,
+				test.expected,
+				content)
+		}
+	}
+}
+
+func scoreboardContent(statuses ...string) *FantasyContent {
+	matchups := make([]Matchup, len(statuses))
+	for i, status := range statuses {
+		matchups[i] = Matchup{Status: status}
+	}
+	return &FantasyContent{
+		League: League{
+			CurrentWeek: 5,
+			Scoreboard:  Scoreboard{Matchups: matchups},
+		},
+	}
+}
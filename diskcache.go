@@ -0,0 +1,308 @@
+package goff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//
+// Disk Cache
+//
+
+const (
+	// diskCacheEntryExt is the extension of files holding cached content.
+	diskCacheEntryExt = ".json"
+	// diskCacheTempExt is the extension of files being written that have not
+	// yet replaced an entry.
+	diskCacheTempExt = ".tmp"
+	// staleTempFileAge is how old a temporary file must be before it is
+	// assumed to be left over from a process that stopped while writing it.
+	staleTempFileAge = time.Hour
+	// diskCacheScanWrites is how many writes a process makes between scans of
+	// the directory, so that content written by other processes and leftover
+	// temporary files are eventually accounted for.
+	diskCacheScanWrites = 1000
+	// diskCacheEvictFraction means content is evicted until the cache is a
+	// tenth below its maximum size, so a full cache is not scanned on every
+	// write.
+	diskCacheEvictFraction = 10
+)
+
+// DiskCache implements Cache by storing content in files in a directory, so
+// that it survives restarts and can be shared by processes on the same host.
+// Content is cached for up to a maximum duration after it was set. Once the
+// files in the directory exceed the maximum size, the least recently used
+// content is removed. The size of the directory is tracked as content is
+// written, and it is only scanned again once that size exceeds the maximum or
+// after many writes.
+//
+// Each piece of content is written to a temporary file that then replaces its
+// entry, so other processes never read partially written content. Content
+// that can't be read or written is treated as missing from the cache.
+type DiskCache struct {
+	ClientID string
+	Duration time.Duration
+	// Directory holding the cached content
+	Dir string
+	// Maximum total size in bytes of the cached content, or zero for no limit
+	MaxSize int64
+
+	// Guards eviction of content by this process and the fields below
+	mutex sync.Mutex
+	// Estimated total size of the cached content, valid once scanned is set
+	size    int64
+	scanned bool
+	// Number of writes since the directory was last scanned
+	writes int
+}
+
+// diskCacheEntry is the content stored in a single file of a DiskCache.
+type diskCacheEntry struct {
+	Key     string          `json:"key"`
+	Time    time.Time       `json:"time"`
+	TTL     time.Duration   `json:"ttl"`
+	Content *FantasyContent `json:"content"`
+}
+
+// NewDiskCache creates a new Cache that stores content for the given client
+// in the given directory for up to the maximum duration. The directory is
+// created if it does not exist. Once the cached content exceeds maxSize bytes
+// the least recently used content is removed, unless maxSize is zero.
+//
+// See NewCachedClient
+func NewDiskCache(
+	clientID string,
+	duration time.Duration,
+	dir string,
+	maxSize int64) (*DiskCache, error) {
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+	return &DiskCache{
+		ClientID: clientID,
+		Duration: duration,
+		Dir:      dir,
+		MaxSize:  maxSize,
+	}, nil
+}
+
+// Set specifies that the given content was retrieved for the given URL at the
+// given time. The content for that URL will be available by DiskCache.Get
+// from the given 'time' up to 'time + d.Duration'
+func (d *DiskCache) Set(url string, time time.Time, content *FantasyContent) {
+	d.SetWithTTL(url, time, content, 0)
+}
+
+// SetWithTTL specifies that the given content was retrieved for the given URL
+// at the given time and is valid for the given TTL instead of d.Duration. A
+// TTL of zero uses d.Duration, and NoExpiration keeps the content until it is
+// evicted.
+func (d *DiskCache) SetWithTTL(url string, time time.Time, content *FantasyContent, ttl time.Duration) {
+	key := d.getKey(url)
+	bits, err := json.Marshal(&diskCacheEntry{
+		Key:     key,
+		Time:    time,
+		TTL:     ttl,
+		Content: content,
+	})
+	if err != nil {
+		return
+	}
+
+	path := d.path(key)
+	var replaced int64
+	if info, err := os.Stat(path); err == nil {
+		replaced = info.Size()
+	}
+	if err := d.write(path, bits, time); err != nil {
+		return
+	}
+	d.evict(int64(len(bits)) - replaced)
+}
+
+// Get the content for the given URL at the given time. Content that has
+// expired by the given time is removed from the cache.
+func (d *DiskCache) Get(url string, time time.Time) (content *FantasyContent, ok bool) {
+	key := d.getKey(url)
+	path := d.path(key)
+	bits, info, err := readFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskCacheEntry
+	if err := json.Unmarshal(bits, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+	if d.isExpired(&entry, time) {
+		removeIfUnchanged(path, info)
+		return nil, false
+	}
+	if time.Before(entry.Time) {
+		return nil, false
+	}
+
+	// The modification time of each file records when it was last used
+	os.Chtimes(path, time, time)
+	return entry.Content, true
+}
+
+// isExpired returns whether the cached entry is no longer valid at the given
+// time.
+func (d *DiskCache) isExpired(entry *diskCacheEntry, time time.Time) bool {
+	ttl := entry.TTL
+	if ttl == 0 {
+		ttl = d.Duration
+	}
+	if ttl < 0 {
+		return false
+	}
+	return !time.Before(entry.Time.Add(ttl))
+}
+
+// getKey converts a base key to a key that is unique for the client of the
+// DiskCache, using the same format as LRUCache.
+func (d *DiskCache) getKey(originalKey string) string {
+	return fmt.Sprintf("%s:%s", d.ClientID, originalKey)
+}
+
+// path returns the file holding the content for the given key.
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:])+diskCacheEntryExt)
+}
+
+// readFile returns the contents of the file at the given path along with the
+// information of the file that was read.
+func readFile(path string) ([]byte, fs.FileInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	bits, err := io.ReadAll(file)
+	return bits, info, err
+}
+
+// removeIfUnchanged removes the file at the given path unless another process
+// has replaced it since it was read.
+func removeIfUnchanged(path string, read fs.FileInfo) {
+	current, err := os.Stat(path)
+	if err == nil && os.SameFile(read, current) {
+		os.Remove(path)
+	}
+}
+
+// write atomically replaces the file at the given path with the given data,
+// last used at the given time.
+func (d *DiskCache) write(path string, bits []byte, time time.Time) error {
+	file, err := os.CreateTemp(d.Dir, "*"+diskCacheTempExt)
+	if err != nil {
+		return err
+	}
+	tempPath := file.Name()
+
+	_, err = file.Write(bits)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chtimes(tempPath, time, time)
+	}
+	if err == nil {
+		err = os.Rename(tempPath, path)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+	}
+	return err
+}
+
+// evict records that the cached content grew by the given number of bytes and
+// scans the directory if it may now exceed the maximum size.
+func (d *DiskCache) evict(added int64) {
+	if d.MaxSize <= 0 {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.size += added
+	d.writes++
+	if d.scanned && d.size <= d.MaxSize && d.writes < diskCacheScanWrites {
+		return
+	}
+	d.scan()
+}
+
+// scan removes the least recently used content once the cached content
+// exceeds the maximum size, until it is a fraction below the maximum, and
+// records the size that remains.
+// Temporary files left over by processes that stopped while writing are also
+// removed.
+func (d *DiskCache) scan() {
+	dirEntries, err := os.ReadDir(d.Dir)
+	if err != nil {
+		return
+	}
+
+	var files []fs.FileInfo
+	var size int64
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		name := info.Name()
+		if strings.HasSuffix(name, diskCacheTempExt) {
+			if time.Since(info.ModTime()) > staleTempFileAge {
+				os.Remove(filepath.Join(d.Dir, name))
+			}
+			continue
+		}
+		if strings.HasSuffix(name, diskCacheEntryExt) {
+			files = append(files, info)
+			size += info.Size()
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	target := d.MaxSize
+	if size > d.MaxSize {
+		target -= d.MaxSize / diskCacheEvictFraction
+	}
+	for _, file := range files {
+		if size <= target {
+			break
+		}
+		// Another process may have already removed the file
+		err := os.Remove(filepath.Join(d.Dir, file.Name()))
+		if err == nil || errors.Is(err, fs.ErrNotExist) {
+			size -= file.Size()
+		}
+	}
+
+	d.size = size
+	d.scanned = true
+	d.writes = 0
+}
@@ -0,0 +1,339 @@
+package goff
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+//
+// Test DiskCache
+//
+
+func TestNewDiskCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache, err := NewDiskCache("clientID", time.Hour, dir, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	assertStringEquals(t, "clientID", cache.ClientID)
+	assertStringEquals(t, dir, cache.Dir)
+	if cache.Duration != time.Hour {
+		t.Fatalf("Unexpected duration in cache\n\texpected: %s\n\tactual: %s",
+			time.Hour,
+			cache.Duration)
+	}
+	if cache.MaxSize != 1024 {
+		t.Fatalf("Unexpected max size in cache\n\texpected: %d\n\tactual: %d",
+			1024,
+			cache.MaxSize)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Fatalf("Cache directory not created: %s", err)
+	}
+}
+
+func TestNewDiskCacheInvalidDir(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, []byte{}, 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cache, err := NewDiskCache("clientID", time.Hour, filepath.Join(file, "cache"), 0)
+	if err == nil {
+		t.Fatalf("no error returned for invalid directory: %+v", cache)
+	}
+}
+
+func TestDiskCacheSetAndGet(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewDiskCache("clientID", time.Hour, dir, 0)
+	expectedContent := diskCacheContent(t)
+
+	setTime := time.Unix(1408281677, 0)
+	url := "http://example.com/league/314.l.1/scoreboard;week=15"
+	cache.Set(url, setTime, expectedContent)
+
+	content, ok := cache.Get(url, setTime.Add(time.Minute))
+	if !ok {
+		t.Fatal("Cache did not return content")
+	}
+	if !reflect.DeepEqual(expectedContent, content) {
+		t.Fatalf("Cache did not return expected content\n\texpected: %+v"+
+			"\n\tactual: %+v",
+			expectedContent,
+			content)
+	}
+
+	// Content survives restarts
+	restarted, _ := NewDiskCache("clientID", time.Hour, dir, 0)
+	content, ok = restarted.Get(url, setTime.Add(time.Minute))
+	if !ok || !reflect.DeepEqual(expectedContent, content) {
+		t.Fatalf("Cache did not return content after restart: %+v", content)
+	}
+
+	// Content is unique to each client
+	otherClient, _ := NewDiskCache("otherClientID", time.Hour, dir, 0)
+	if content, ok := otherClient.Get(url, setTime.Add(time.Minute)); ok {
+		t.Fatalf("Cache returned content for another client: %+v", content)
+	}
+}
+
+func TestDiskCacheGetNoContent(t *testing.T) {
+	cache, _ := NewDiskCache("clientID", time.Hour, t.TempDir(), 0)
+
+	content, ok := cache.Get("http://example.com/fantasy", time.Unix(1408281677, 0))
+	if ok {
+		t.Fatalf("Cache returned content when it should not have been cached"+
+			"content: %+v",
+			content)
+	}
+}
+
+func TestDiskCacheGetInvalidContent(t *testing.T) {
+	cache, _ := NewDiskCache("clientID", time.Hour, t.TempDir(), 0)
+	url := "http://example.com/fantasy"
+	path := cache.path(cache.getKey(url))
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content, ok := cache.Get(url, time.Unix(1408281677, 0))
+	if ok {
+		t.Fatalf("Cache returned invalid content: %+v", content)
+	}
+}
+
+func TestDiskCacheExpiresDurationAfterSet(t *testing.T) {
+	duration := time.Hour
+	cache, _ := NewDiskCache("clientID", duration, t.TempDir(), 0)
+
+	setTime := time.Unix(1408283999, 0)
+	url := "http://example.com/fantasy"
+	cache.Set(url, setTime, createLeagueList(League{LeagueKey: "123"}))
+
+	if _, ok := cache.Get(url, setTime.Add(-time.Second)); ok {
+		t.Fatal("Cache returned content before it was set")
+	}
+	if _, ok := cache.Get(url, setTime.Add(duration-time.Second)); !ok {
+		t.Fatal("Cache did not return content before it expired")
+	}
+	if content, ok := cache.Get(url, setTime.Add(duration)); ok {
+		t.Fatalf("Cache returned expired content: %+v", content)
+	}
+	if _, err := os.Stat(cache.path(cache.getKey(url))); !os.IsNotExist(err) {
+		t.Fatalf("Expired content not removed: %v", err)
+	}
+}
+
+func TestDiskCacheKeepsReplacedExpiredContent(t *testing.T) {
+	cache, _ := NewDiskCache("clientID", time.Hour, t.TempDir(), 0)
+	setTime := time.Unix(1408281677, 0)
+	url := "http://example.com/fantasy"
+	path := cache.path(cache.getKey(url))
+
+	cache.Set(url, setTime, &FantasyContent{})
+	_, read, err := readFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Another process replaces the expired content before it is removed
+	cache.Set(url, setTime.Add(time.Hour), createLeagueList(League{LeagueKey: "123"}))
+	removeIfUnchanged(path, read)
+
+	if _, ok := cache.Get(url, setTime.Add(time.Hour)); !ok {
+		t.Fatal("Cache removed content that replaced expired content")
+	}
+}
+
+func TestDiskCacheSetWithTTL(t *testing.T) {
+	cache, _ := NewDiskCache("clientID", time.Hour, t.TempDir(), 0)
+
+	setTime := time.Unix(1408281677, 0)
+	liveURL := "http://example.com/league/223.l.431/scoreboard"
+	finishedURL := "http://example.com/league/223.l.1"
+	cache.SetWithTTL(liveURL, setTime, &FantasyContent{}, 30*time.Second)
+	cache.SetWithTTL(finishedURL, setTime, &FantasyContent{}, NoExpiration)
+
+	if _, ok := cache.Get(liveURL, setTime.Add(29*time.Second)); !ok {
+		t.Fatal("Cache did not return content before it expired")
+	}
+	if _, ok := cache.Get(liveURL, setTime.Add(30*time.Second)); ok {
+		t.Fatal("Cache returned expired content")
+	}
+	if _, ok := cache.Get(finishedURL, setTime.Add(365*24*time.Hour)); !ok {
+		t.Fatal("Cache did not return content without expiration")
+	}
+}
+
+func TestDiskCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	setTime := time.Unix(1408281677, 0)
+	content := createLeagueList(League{LeagueKey: "123"})
+
+	// Find the size of a single entry
+	sizer, _ := NewDiskCache("clientID", time.Hour, t.TempDir(), 0)
+	sizer.Set("http://example.com/league/1", setTime, content)
+	info, err := os.Stat(sizer.path(sizer.getKey("http://example.com/league/1")))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Evicting a single entry brings the cache a tenth below its maximum size
+	cache, _ := NewDiskCache("clientID", time.Hour, dir, 2*info.Size()+info.Size()/2)
+	cache.Set("http://example.com/league/1", setTime, content)
+	cache.Set("http://example.com/league/2", setTime.Add(time.Second), content)
+	if _, ok := cache.Get("http://example.com/league/1", setTime.Add(2*time.Second)); !ok {
+		t.Fatal("Cache did not return content before it was evicted")
+	}
+	cache.Set("http://example.com/league/3", setTime.Add(3*time.Second), content)
+
+	getTime := setTime.Add(4 * time.Second)
+	if _, ok := cache.Get("http://example.com/league/2", getTime); ok {
+		t.Fatal("Least recently used content was not evicted")
+	}
+	for _, url := range []string{"http://example.com/league/1", "http://example.com/league/3"} {
+		if _, ok := cache.Get(url, getTime); !ok {
+			t.Fatalf("Recently used content was evicted for %s", url)
+		}
+	}
+}
+
+func TestDiskCacheTracksSizeBetweenScans(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewDiskCache("clientID", time.Hour, dir, 1024*1024)
+	setTime := time.Unix(1408281677, 0)
+
+	cache.Set("http://example.com/league/1", setTime, &FantasyContent{})
+	leftover := filepath.Join(dir, "leftover"+diskCacheTempExt)
+	if err := os.WriteFile(leftover, []byte("{"), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	staleTime := time.Now().Add(-2 * staleTempFileAge)
+	if err := os.Chtimes(leftover, staleTime, staleTime); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cache.Set("http://example.com/league/2", setTime, &FantasyContent{})
+	cache.Set("http://example.com/league/1", setTime, createLeagueList(League{LeagueKey: "123"}))
+
+	// The directory is not scanned again while it is below the maximum size
+	if _, err := os.Stat(leftover); err != nil {
+		t.Fatalf("Directory scanned before exceeding maximum size: %s", err)
+	}
+	var expected int64
+	for _, url := range []string{"http://example.com/league/1", "http://example.com/league/2"} {
+		info, err := os.Stat(cache.path(cache.getKey(url)))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected += info.Size()
+	}
+	if cache.size != expected {
+		t.Fatalf("Unexpected size of cache\n\texpected: %d\n\tactual: %d",
+			expected,
+			cache.size)
+	}
+}
+
+func TestDiskCacheRemovesStaleTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewDiskCache("clientID", time.Hour, dir, 1024*1024)
+
+	stale := filepath.Join(dir, "stale"+diskCacheTempExt)
+	fresh := filepath.Join(dir, "fresh"+diskCacheTempExt)
+	for _, path := range []string{stale, fresh} {
+		if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	staleTime := time.Now().Add(-2 * staleTempFileAge)
+	if err := os.Chtimes(stale, staleTime, staleTime); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cache.Set("http://example.com/fantasy", time.Now(), &FantasyContent{})
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("Stale temporary file not removed: %v", err)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Fatalf("Temporary file being written was removed: %s", err)
+	}
+}
+
+func TestDiskCacheConcurrentProcesses(t *testing.T) {
+	dir := t.TempDir()
+	setTime := time.Unix(1408281677, 0)
+
+	var wg sync.WaitGroup
+	for process := 0; process < 4; process++ {
+		// Each cache acts like a separate process sharing the directory
+		cache, _ := NewDiskCache("clientID", time.Hour, dir, 4096)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				url := fmt.Sprintf("http://example.com/league/223.l.%d", i%5)
+				leagueKey := fmt.Sprintf("223.l.%d", i%5)
+				cache.Set(url, setTime, createLeagueList(League{LeagueKey: leagueKey}))
+				content, ok := cache.Get(url, setTime)
+				if ok && content.Users[0].Games[0].Leagues[0].LeagueKey != leagueKey {
+					t.Errorf("Unexpected content for %s: %+v", url, content)
+				}
+			}(i)
+		}
+	}
+	wg.Wait()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != diskCacheEntryExt {
+			t.Fatalf("Unexpected file left in cache: %s", entry.Name())
+		}
+	}
+}
+
+func TestDiskCacheWithCachedClient(t *testing.T) {
+	cache, _ := NewDiskCache("clientID", time.Hour, t.TempDir(), 0)
+	httpClient := &mockHTTPClient{Response: mockResponse(leagueXMLContent)}
+	client := NewCachedClient(cache, httpClient)
+
+	league, err := client.GetLeagueMetadata(expectedLeague.LeagueKey)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+	assertLeaguesEqual(t, []League{expectedLeague}, []League{*league})
+
+	league, err = client.GetLeagueMetadata(expectedLeague.LeagueKey)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+	assertLeaguesEqual(t, []League{expectedLeague}, []League{*league})
+	assertIntEquals(t, 1, httpClient.RequestCount)
+}
+
+// diskCacheContent returns content parsed from a response, to make sure all
+// of its fields can be stored.
+func diskCacheContent(t *testing.T) *FantasyContent {
+	provider := &xmlContentProvider{
+		client: &countingHTTPApiClient{
+			client: &mockHTTPClient{Response: mockResponse(scoreboardXMLContent)},
+		},
+	}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return content
+}
@@ -0,0 +1,84 @@
+package goff
+
+import (
+	"context"
+	"fmt"
+)
+
+//
+// Draft Results
+//
+
+// A DraftResult is a single pick made during a league's draft.
+type DraftResult struct {
+	Pick      int    `xml:"pick"`
+	Round     int    `xml:"round"`
+	TeamKey   string `xml:"team_key"`
+	PlayerKey string `xml:"player_key"`
+	// Amount paid for the player in auction drafts
+	Cost int `xml:"cost"`
+	// Details of the drafted player, only available when requested using
+	// GetDraftResultsWithPlayers
+	Player Player `xml:"player"`
+}
+
+// GetDraftResults returns the picks made during the draft of the given
+// league, in the order they were made.
+func (c *Client) GetDraftResults(leagueKey string) ([]DraftResult, error) {
+	return c.GetDraftResultsContext(context.Background(), leagueKey)
+}
+
+// GetDraftResultsContext returns the picks made during the draft of the given
+// league using the given context for the API request.
+func (c *Client) GetDraftResultsContext(ctx context.Context, leagueKey string) ([]DraftResult, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/league/%s/draftresults",
+			c.baseURL(),
+			leagueKey))
+	if err != nil {
+		return nil, err
+	}
+	return content.League.DraftResults, nil
+}
+
+// GetDraftResultsWithPlayers returns the picks made during the draft of the
+// given league along with the name and positions of each drafted player.
+func (c *Client) GetDraftResultsWithPlayers(leagueKey string) ([]DraftResult, error) {
+	return c.GetDraftResultsWithPlayersContext(context.Background(), leagueKey)
+}
+
+// GetDraftResultsWithPlayersContext returns the picks made during the draft
+// of the given league along with each drafted player using the given context
+// for the API request.
+func (c *Client) GetDraftResultsWithPlayersContext(ctx context.Context, leagueKey string) ([]DraftResult, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/league/%s/draftresults/players",
+			c.baseURL(),
+			leagueKey))
+	if err != nil {
+		return nil, err
+	}
+	return content.League.DraftResults, nil
+}
+
+// GetTeamDraftResults returns the picks made by the given team during its
+// league's draft.
+func (c *Client) GetTeamDraftResults(teamKey string) ([]DraftResult, error) {
+	return c.GetTeamDraftResultsContext(context.Background(), teamKey)
+}
+
+// GetTeamDraftResultsContext returns the picks made by the given team during
+// its league's draft using the given context for the API request.
+func (c *Client) GetTeamDraftResultsContext(ctx context.Context, teamKey string) ([]DraftResult, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/team/%s/draftresults",
+			c.baseURL(),
+			teamKey))
+	if err != nil {
+		return nil, err
+	}
+	return content.Team.DraftResults, nil
+}
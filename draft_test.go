@@ -0,0 +1,189 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+//
+// Test GetDraftResults
+//
+
+func TestGetDraftResults(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			League: League{DraftResults: expectedDraftResults},
+		},
+		err: nil,
+	}
+	client := &Client{Provider: provider}
+
+	results, err := client.GetDraftResults("223.l.431")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertDraftResultsEqual(t, expectedDraftResults, results)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/223.l.431/draftresults",
+		provider.lastGetURL)
+}
+
+func TestGetDraftResultsError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetDraftResults("223.l.431")
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+func TestGetDraftResultsWithPlayers(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			League: League{DraftResults: expectedDraftResults},
+		},
+		err: nil,
+	}
+	client := &Client{Provider: provider}
+
+	results, err := client.GetDraftResultsWithPlayers("223.l.431")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertDraftResultsEqual(t, expectedDraftResults, results)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/223.l.431/draftresults/players",
+		provider.lastGetURL)
+}
+
+func TestGetDraftResultsWithPlayersError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetDraftResultsWithPlayers("223.l.431")
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+func TestGetTeamDraftResults(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			Team: Team{DraftResults: expectedDraftResults[:1]},
+		},
+		err: nil,
+	}
+	client := &Client{Provider: provider}
+
+	results, err := client.GetTeamDraftResults("223.l.431.t.1")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertDraftResultsEqual(t, expectedDraftResults[:1], results)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/team/223.l.431.t.1/draftresults",
+		provider.lastGetURL)
+}
+
+func TestGetTeamDraftResultsError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetTeamDraftResults("223.l.431.t.1")
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+func TestXMLContentProviderGetDraftResults(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(draftResultsXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	results := content.League.DraftResults
+	assertDraftResultsEqual(t, expectedDraftResults, results)
+
+	player := results[0].Player
+	assertStringEquals(t, "Adrian Peterson", player.Name.Full)
+	assertStringEquals(t, "RB", player.DisplayPosition)
+	assertStringEquals(t, "", results[1].Player.PlayerKey)
+}
+
+func assertDraftResultsEqual(t *testing.T, expected []DraftResult, actual []DraftResult) {
+	if len(expected) != len(actual) {
+		t.Fatalf("Unexpected draft results\n\texpected: %+v\n\tactual: %+v",
+			expected,
+			actual)
+	}
+	for i := range expected {
+		assertIntEquals(t, expected[i].Pick, actual[i].Pick)
+		assertIntEquals(t, expected[i].Round, actual[i].Round)
+		assertStringEquals(t, expected[i].TeamKey, actual[i].TeamKey)
+		assertStringEquals(t, expected[i].PlayerKey, actual[i].PlayerKey)
+		assertIntEquals(t, expected[i].Cost, actual[i].Cost)
+	}
+}
+
+var expectedDraftResults = []DraftResult{
+	DraftResult{
+		Pick:      1,
+		Round:     1,
+		TeamKey:   "223.l.431.t.1",
+		PlayerKey: "223.p.8261",
+		Cost:      61,
+	},
+	DraftResult{
+		Pick:      2,
+		Round:     1,
+		TeamKey:   "223.l.431.t.2",
+		PlayerKey: "223.p.1234",
+		Cost:      0,
+	},
+}
+
+var draftResultsXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431/draftresults/players" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="181.80584907532ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <league>
+    <league_key>223.l.431</league_key>
+    <league_id>431</league_id>
+    <name>League Name</name>
+    <draft_status>postdraft</draft_status>
+    <draft_results count="2">
+      <draft_result>
+        <pick>1</pick>
+        <round>1</round>
+        <cost>61</cost>
+        <team_key>223.l.431.t.1</team_key>
+        <player_key>223.p.8261</player_key>
+        <player>
+          <player_key>223.p.8261</player_key>
+          <player_id>8261</player_id>
+          <name>
+            <full>Adrian Peterson</full>
+            <first>Adrian</first>
+            <last>Peterson</last>
+          </name>
+          <display_position>RB</display_position>
+        </player>
+      </draft_result>
+      <draft_result>
+        <pick>2</pick>
+        <round>1</round>
+        <team_key>223.l.431.t.2</team_key>
+        <player_key>223.p.1234</player_key>
+      </draft_result>
+    </draft_results>
+  </league>
+</fantasy_content>`
@@ -0,0 +1,188 @@
+package goff
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// API Errors
+//
+
+// StatusRateLimited is the non-standard HTTP status code Yahoo uses when it
+// throttles requests made by an application.
+const StatusRateLimited = 999
+
+// maxErrorBodySize is the most of an error response that will be read when
+// looking for the error description provided by Yahoo.
+const maxErrorBodySize = 64 * 1024
+
+var (
+	// ErrBadRequest is matched by an APIError when Yahoo rejected the request
+	// as invalid, for example when adding a player that is not available or
+	// starting a player at a position they are not eligible for.
+	ErrBadRequest = errors.New("request was rejected as invalid")
+
+	// ErrNotFound is matched by an APIError when the requested resource does
+	// not exist.
+	ErrNotFound = errors.New("requested resource could not be found")
+
+	// ErrUnauthorized is matched by an APIError when the request was not
+	// properly authenticated, for example because the access token expired.
+	ErrUnauthorized = errors.New("request is not authorized")
+
+	// ErrRateLimited is matched by an APIError when Yahoo throttled the
+	// request.
+	ErrRateLimited = errors.New("request was rate limited")
+
+	// ErrServerError is matched by an APIError when Yahoo failed to process
+	// the request due to an error on its end.
+	ErrServerError = errors.New("fantasy sports API returned a server error")
+)
+
+// APIError is returned when the Yahoo fantasy sports API responds to a request
+// with an error status code.
+//
+// Use errors.Is to compare an APIError with ErrBadRequest, ErrNotFound,
+// ErrUnauthorized, ErrAccessDenied, ErrRateLimited, or ErrServerError.
+type APIError struct {
+	// HTTP status code of the response
+	StatusCode int
+	// Description of the error provided by Yahoo, if any
+	Description string
+	// URL of the failed request
+	URL string
+	// Whether the same request may succeed if tried again later
+	Retryable bool
+	// How long Yahoo asked to wait before retrying the request, if at all
+	RetryAfter time.Duration
+}
+
+// yahooError is the body of an error response from the fantasy sports API.
+type yahooError struct {
+	XMLName     xml.Name `xml:"error"`
+	Description string   `xml:"description"`
+}
+
+// Error describes the status code and description returned by Yahoo.
+func (e *APIError) Error() string {
+	description := e.Description
+	if description == "" {
+		description = http.StatusText(e.StatusCode)
+	}
+	if description == "" && e.StatusCode == StatusRateLimited {
+		description = "Request denied"
+	}
+	return fmt.Sprintf("fantasy sports API request failed with status %d: %s (url=%s)",
+		e.StatusCode,
+		description,
+		e.URL)
+}
+
+// Is reports whether the error represents the type of failure described by
+// one of the sentinel errors in this package.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest ||
+			e.StatusCode == http.StatusConflict
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrAccessDenied:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == StatusRateLimited ||
+			e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError &&
+			e.StatusCode < 600
+	}
+	return false
+}
+
+// checkResponse returns an APIError if the response has an error status code.
+// The body of the response will be consumed and closed when an error is
+// returned.
+func checkResponse(url string, response *http.Response) error {
+	if response == nil || response.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		URL:        url,
+		Retryable:  isRetryableStatus(response.StatusCode),
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+	}
+
+	if response.Body != nil {
+		defer response.Body.Close()
+		bits, err := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+		if err == nil {
+			apiErr.Description = parseErrorDescription(bits)
+		}
+	}
+
+	// Yahoo intermittently rejects valid consumer keys, see IsRetryable
+	if apiErr.StatusCode == http.StatusUnauthorized &&
+		strings.Contains(apiErr.Description, "consumer_key_unknown") {
+		apiErr.Retryable = true
+	}
+	return apiErr
+}
+
+// parseErrorDescription returns the description from a Yahoo error response,
+// in either XML or JSON, or an empty string if the response could not be
+// parsed.
+func parseErrorDescription(bits []byte) string {
+	var content yahooError
+	if err := xml.Unmarshal(bits, &content); err == nil {
+		return content.Description
+	}
+	if err := unmarshalJSONContent(bits, &content); err == nil {
+		return content.Description
+	}
+	return ""
+}
+
+// parseRetryAfter converts the value of a Retry-After header, given either in
+// seconds or as a HTTP date, to the duration to wait from the given time.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// isRetryableStatus returns whether a request that failed with the given
+// status code may succeed if it is tried again later.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case StatusRateLimited,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+//
+// Test APIError
+//
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{
+		ErrBadRequest,
+		ErrNotFound,
+		ErrUnauthorized,
+		ErrAccessDenied,
+		ErrRateLimited,
+		ErrServerError,
+	}
+	tests := map[int]error{
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrAccessDenied,
+		StatusRateLimited:              ErrRateLimited,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusInternalServerError: ErrServerError,
+		http.StatusServiceUnavailable:  ErrServerError,
+		http.StatusBadRequest:          ErrBadRequest,
+		http.StatusConflict:            ErrBadRequest,
+		http.StatusMethodNotAllowed:    nil,
+	}
+
+	for statusCode, expected := range tests {
+		err := &APIError{StatusCode: statusCode}
+		for _, sentinel := range sentinels {
+			if errors.Is(err, sentinel) != (sentinel == expected) {
+				t.Fatalf("Unexpected result comparing errors\n"+
+					"\tstatus: %d\n\tsentinel: %s\n\texpected match: %t",
+					statusCode,
+					sentinel,
+					sentinel == expected)
+			}
+		}
+	}
+}
+
+func TestAPIErrorAs(t *testing.T) {
+	expected := &APIError{
+		StatusCode:  http.StatusNotFound,
+		Description: "Invalid league key.",
+		URL:         "http://example.com",
+	}
+	wrapped := fmt.Errorf("getting league: %w", expected)
+
+	var actual *APIError
+	if !errors.As(wrapped, &actual) {
+		t.Fatalf("APIError not found in wrapped error: %s", wrapped)
+	}
+
+	if actual != expected {
+		t.Fatalf("Unexpected error returned\n\texpected: %+v\n\tactual: %+v",
+			expected,
+			actual)
+	}
+
+	if !errors.Is(wrapped, ErrNotFound) {
+		t.Fatalf("Wrapped error did not match ErrNotFound: %s", wrapped)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &APIError{
+		StatusCode:  http.StatusNotFound,
+		Description: "Invalid league key.",
+		URL:         "http://example.com/league/1",
+	}
+
+	message := err.Error()
+	for _, expected := range []string{"404", err.Description, err.URL} {
+		if !strings.Contains(message, expected) {
+			t.Fatalf("Error message missing content\n\texpected: %s\n\t"+
+				"actual: %s",
+				expected,
+				message)
+		}
+	}
+
+	err = &APIError{StatusCode: StatusRateLimited}
+	if !strings.Contains(err.Error(), "999") {
+		t.Fatalf("Error message missing status code: %s", err.Error())
+	}
+}
+
+//
+// Test checkResponse
+//
+
+func TestCheckResponseSuccess(t *testing.T) {
+	response := mockResponse("content")
+	response.StatusCode = http.StatusOK
+	if err := checkResponse("http://example.com", response); err != nil {
+		t.Fatalf("Unexpected error for successful response: %s", err)
+	}
+}
+
+func TestCheckResponseParsesDescription(t *testing.T) {
+	response := mockResponse(errorXMLContent)
+	response.StatusCode = http.StatusBadRequest
+	url := "http://example.com/league/223.l.1"
+
+	err := checkResponse(url, response)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("APIError not returned: %+v", err)
+	}
+
+	assertIntEquals(t, http.StatusBadRequest, apiErr.StatusCode)
+	assertStringEquals(t, "Invalid league key.", apiErr.Description)
+	assertStringEquals(t, url, apiErr.URL)
+	assertBoolEquals(t, false, apiErr.Retryable)
+
+	if !response.Body.(*mockReaderCloser).WasClosed {
+		t.Fatal("Response body not closed after reading error")
+	}
+}
+
+func TestCheckResponseParsesJSONDescription(t *testing.T) {
+	response := mockResponse(errorJSONContent)
+	response.StatusCode = http.StatusBadRequest
+
+	err := checkResponse("http://example.com/league/223.l.1?format=json", response)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("APIError not returned: %+v", err)
+	}
+
+	assertStringEquals(t, "Invalid league key.", apiErr.Description)
+}
+
+func TestCheckResponseConsumerKeyUnknown(t *testing.T) {
+	response := mockResponse(consumerKeyUnknownXMLContent)
+	response.StatusCode = http.StatusUnauthorized
+
+	err := checkResponse("http://example.com", response)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("APIError not returned: %+v", err)
+	}
+
+	assertBoolEquals(t, true, apiErr.Retryable)
+	assertBoolEquals(t, true, IsRetryable(err))
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Error did not match ErrUnauthorized: %s", err)
+	}
+
+	// Other authorization failures are not retried
+	response = mockResponse(errorXMLContent)
+	response.StatusCode = http.StatusUnauthorized
+	assertBoolEquals(t, false, IsRetryable(checkResponse("http://example.com", response)))
+}
+
+func TestCheckResponseUnparseableBody(t *testing.T) {
+	response := mockResponse("<html>Request denied</html>")
+	response.StatusCode = StatusRateLimited
+
+	err := checkResponse("http://example.com", response)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("APIError not returned: %+v", err)
+	}
+
+	assertStringEquals(t, "", apiErr.Description)
+	assertBoolEquals(t, true, apiErr.Retryable)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Error did not match ErrRateLimited: %s", err)
+	}
+}
+
+func TestXMLContentProviderAPIError(t *testing.T) {
+	response := mockResponse(errorXMLContent)
+	response.StatusCode = http.StatusNotFound
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{
+			Response: response,
+		},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+
+	if content != nil {
+		t.Fatalf("Content returned for error response: %+v", content)
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %s",
+			ErrNotFound,
+			err)
+	}
+}
+
+var errorXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<error xml:lang="en-us" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.1" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" xmlns="http://www.yahooapis.com/v1/base.rng">
+  <description>Invalid league key.</description>
+  <detail/>
+</error>`
+
+var consumerKeyUnknownXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<yahoo:error xmlns:yahoo="http://yahooapis.com/v1/base.rng" xml:lang="en-US">
+  <yahoo:description>Please provide valid credentials. OAuth oauth_problem="consumer_key_unknown", realm="yahooapis.com"</yahoo:description>
+</yahoo:error>`
+
+var errorJSONContent = `{"error":{"xml:lang":"en-us","yahoo:uri":"http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.1?format=json","description":"Invalid league key.","detail":""}}`
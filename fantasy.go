@@ -40,13 +40,18 @@
 package goff
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	neturl "net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mrjones/oauth"
@@ -62,6 +67,15 @@ const (
 	// NflGameKey represents the current year's Yahoo fantasy football game
 	NflGameKey = "nfl"
 
+	// MlbGameKey represents the current year's Yahoo fantasy baseball game
+	MlbGameKey = "mlb"
+
+	// NbaGameKey represents the current year's Yahoo fantasy basketball game
+	NbaGameKey = "nba"
+
+	// NhlGameKey represents the current year's Yahoo fantasy hockey game
+	NhlGameKey = "nhl"
+
 	// YahooBaseURL is the base URL for all calls to Yahoo's fantasy sports API
 	YahooBaseURL = "https://fantasysports.yahooapis.com/fantasy/v2"
 
@@ -81,15 +95,81 @@ const (
 	// YahooOauth2TokenURL is used to create OAuth 2 access tokens used when
 	// making calls to the fantasy sports API.
 	YahooOauth2TokenURL = "https://api.login.yahoo.com/oauth2/get_token"
+
+	// ReadScope is the OAuth 2 scope that allows an application to read
+	// fantasy sports data.
+	ReadScope = "fspt-r"
+
+	// ReadWriteScope is the OAuth 2 scope that allows an application to read
+	// and modify fantasy sports data, for example to set lineups or add and
+	// drop players.
+	ReadWriteScope = "fspt-w"
 )
 
 // ErrAccessDenied is returned when the user does not have permision to
-// access the requested resource.
+// access the requested resource. An APIError with a 403 status code will also
+// match this error when using errors.Is.
 var ErrAccessDenied = errors.New(
 	"user does not have permission to access the requested resource")
 
+// Coverage types describe the period of time covered by a Roster, Points, or
+// SelectedPosition.
+const (
+	// WeekCoverage is used by sports that set lineups for a week at a time,
+	// such as football. Week is set for this coverage type.
+	WeekCoverage = "week"
+
+	// DateCoverage is used by sports that set lineups for each day, such as
+	// baseball, basketball, and hockey. Date is set for this coverage type.
+	DateCoverage = "date"
+)
+
+// Statuses of a Matchup
+const (
+	// MatchupPreEvent is the status of a matchup that has not started
+	MatchupPreEvent = "preevent"
+
+	// MatchupMidEvent is the status of a matchup that is in progress
+	MatchupMidEvent = "midevent"
+
+	// MatchupPostEvent is the status of a matchup that has finished
+	MatchupPostEvent = "postevent"
+)
+
+// DateFormat is the layout, for use with time.Parse and time.Format, of the
+// dates used by the fantasy sports API.
+const DateFormat = "2006-01-02"
+
+// maxConcurrentBatches is the most requests made at the same time when a
+// request has to be split into multiple batches.
+const maxConcurrentBatches = 4
+
+// otherResource is the resource type used to count requests for URLs that
+// do not contain a known resource.
+const otherResource = "other"
+
+// apiResources are the resources and collections that can be at the root of
+// a request to the fantasy sports API.
+var apiResources = map[string]bool{
+	"game":         true,
+	"games":        true,
+	"league":       true,
+	"leagues":      true,
+	"player":       true,
+	"players":      true,
+	"team":         true,
+	"teams":        true,
+	"transaction":  true,
+	"transactions": true,
+	"user":         true,
+	"users":        true,
+}
+
 // YearKeys is map of a string year to the string Yahoo uses to identify the
-// fantasy football game for that year.
+// fantasy football game for that year. It is used as a fallback when the game
+// key for a year can not be looked up from the API.
+//
+// See Client.GetGameKey
 var YearKeys = map[string]string{
 	"nfl":  NflGameKey,
 	"2022": "414",
@@ -121,21 +201,42 @@ var YearKeys = map[string]string{
 //
 
 // Client is an application authorized to use the Yahoo fantasy sports API.
+// A Client is safe for concurrent use by multiple goroutines as long as its
+// ContentProvider is.
 type Client struct {
 	// Provides fantasy content for this application.
 	Provider ContentProvider
+
+	// Base URL used to build requests made by the convenience functions.
+	// YahooBaseURL is used when empty.
+	BaseURL string
+
+	// Game keys discovered by GetGameKey keyed by game code and season
+	gameKeysMutex sync.Mutex
+	gameKeys      map[string]string
 }
 
-// ContentProvider returns the data from an API request.
+// ContentProvider returns the data from an API request. Implementations must
+// be safe for concurrent use by multiple goroutines.
 type ContentProvider interface {
-	Get(url string) (content *FantasyContent, err error)
+	// Gets the content for the URL. The given context can be used to cancel
+	// the request.
+	Get(ctx context.Context, url string) (content *FantasyContent, err error)
+	// Sends the XML body to the URL using the given HTTP method, such as
+	// http.MethodPost or http.MethodPut, and returns the content of the
+	// response. Sent content must not be cached.
+	Send(ctx context.Context, method string, url string, body []byte) (content *FantasyContent, err error)
 	// The amount of requests made to the Yahoo API on behalf of the application
 	// represented by this Client.
 	RequestCount() int
+	// The amount of requests made to the Yahoo API keyed by the type of
+	// resource requested, for example "league", "team", "players", or "users".
+	RequestCountByResource() map[string]int
 }
 
 // Cache sets and retrieves fantasy content for request URLs based on the time
-// for which the content was valid
+// for which the content was valid. Implementations must be safe for
+// concurrent use by multiple goroutines.
 type Cache interface {
 	// Sets the content retrieved for the URL at the given time
 	Set(url string, time time.Time, content *FantasyContent)
@@ -146,10 +247,12 @@ type Cache interface {
 }
 
 // LRUCache implements Cache utilizing a LRU cache and unique keys to cache
-// content for up to a maximum duration.
+// content for up to a maximum duration after it was set.
 type LRUCache struct {
-	ClientID        string
-	Duration        time.Duration
+	ClientID string
+	Duration time.Duration
+	// Deprecated: content now expires Duration after it was set instead of
+	// at the end of a period of DurationSeconds. This field is unused.
 	DurationSeconds int64
 	Cache           *lru.LRUCache
 }
@@ -158,15 +261,55 @@ type LRUCache struct {
 // a LRUCache
 type LRUCacheValue struct {
 	content *FantasyContent
+	// When the content was set
+	time time.Time
+	// How long the content is valid, or zero for the Duration of the cache
+	ttl time.Duration
 }
 
 // cachedContentProvider implements ContentProvider and caches data from
 // another ContentProvider for a period of time up to a maximum duration.
+// Concurrent requests for the same URL that miss the cache share a single
+// request to the delegate.
+//
+// Content is cached under the request URL scoped to the user, if any, so that
+// clients sharing a cache on behalf of different users never share content.
 type cachedContentProvider struct {
 	delegate ContentProvider
 	cache    Cache
+	// Identifies the user the content is requested for, may be empty
+	user string
+
+	// Guards inFlight
+	mutex sync.Mutex
+	// Requests to the delegate that have not finished, keyed by cache key
+	inFlight map[string]*sharedRequest
 }
 
+// sharedRequest is a request to a delegate ContentProvider whose result is
+// shared by every caller waiting on it.
+type sharedRequest struct {
+	// Closed once content and err are set
+	done    chan struct{}
+	content *FantasyContent
+	err     error
+	// Number of callers still waiting, guarded by the provider's mutex
+	waiters int
+	// Cancels the request once no callers are waiting
+	cancel context.CancelFunc
+}
+
+// detachedContext keeps the values of a context while ignoring its deadline
+// and cancellation, so a request shared by many callers is not canceled when
+// any one of them gives up.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
 // xmlContentProvider implements ContentProvider and translates XML responses
 // from an httpAPIClient into the appropriate data.
 type xmlContentProvider struct {
@@ -178,21 +321,38 @@ type xmlContentProvider struct {
 // sports API over HTTP
 type httpAPIClient interface {
 	// Makes HTTP request to the API
-	Get(url string) (response *http.Response, err error)
+	Get(ctx context.Context, url string) (response *http.Response, err error)
+	// Makes HTTP request to the API with the given method and XML body
+	Send(ctx context.Context, method string, url string, body []byte) (response *http.Response, err error)
 	// Get the amount of requests made to the API
 	RequestCount() int
+	// Get the amount of requests made to the API by resource type
+	RequestCountByResource() map[string]int
 }
 
-// HTTPClient defines methods needed to communicated with a service over HTTP
+// HTTPClient defines methods needed to communicated with a service over HTTP.
+// This is satisfied by *http.Client.
 type HTTPClient interface {
-	// Makes a HTTP GET request
-	Get(url string) (response *http.Response, err error)
+	// Makes a HTTP request. The request's context will be used to cancel the
+	// request.
+	Do(request *http.Request) (response *http.Response, err error)
 }
 
 // countingHTTPApiClient implements httpAPIClient
 type countingHTTPApiClient struct {
-	client       HTTPClient
-	requestCount int
+	client HTTPClient
+	// Guards requestCount and resourceCounts
+	mutex          sync.Mutex
+	requestCount   int
+	resourceCounts map[string]int
+	// Used to retry failed requests, DefaultRetryPolicy is used if nil
+	retryPolicy *RetryPolicy
+	// Limits the rate of requests, if not nil
+	rateLimiter *RateLimiter
+	// Sent as the User-Agent header of each request, if not empty
+	userAgent string
+	// Maximum duration of each attempt of a request, if positive
+	timeout time.Duration
 }
 
 //
@@ -202,10 +362,13 @@ type countingHTTPApiClient struct {
 // FantasyContent is the root level response containing the data from a request
 // to the fantasy sports API.
 type FantasyContent struct {
-	XMLName xml.Name `xml:"fantasy_content"`
-	League  League   `xml:"league"`
-	Team    Team     `xml:"team"`
-	Users   []User   `xml:"users>user"`
+	XMLName     xml.Name    `xml:"fantasy_content"`
+	Game        Game        `xml:"game"`
+	League      League      `xml:"league"`
+	Team        Team        `xml:"team"`
+	Users       []User      `xml:"users>user"`
+	Games       []Game      `xml:"games>game"`
+	Transaction Transaction `xml:"transaction"`
 }
 
 // User contains the games a user is participating in
@@ -216,26 +379,39 @@ type User struct {
 // Game represents a single year in the Yahoo fantasy football ecosystem. It consists
 // of zero or more leagues.
 type Game struct {
-	Leagues []League `xml:"leagues>league"`
+	GameKey            string         `xml:"game_key"`
+	GameID             uint64         `xml:"game_id"`
+	Name               string         `xml:"name"`
+	Code               string         `xml:"code"`
+	Type               string         `xml:"type"`
+	URL                string         `xml:"url"`
+	Season             string         `xml:"season"`
+	IsRegistrationOver bool           `xml:"is_registration_over"`
+	IsGameOver         bool           `xml:"is_game_over"`
+	IsOffseason        bool           `xml:"is_offseason"`
+	Leagues            []League       `xml:"leagues>league"`
+	StatCategories     []StatCategory `xml:"stat_categories>stats>stat"`
 }
 
 // A League is a uniquely identifiable group of players and teams. The scoring system,
 // roster details, and other metadata can differ between leagues.
 type League struct {
-	LeagueKey   string     `xml:"league_key"`
-	LeagueID    uint64     `xml:"league_id"`
-	Name        string     `xml:"name"`
-	URL         string     `xml:"url"`
-	Players     []Player   `xml:"players>player"`
-	Teams       []Team     `xml:"teams>team"`
-	DraftStatus string     `xml:"draft_status"`
-	CurrentWeek int        `xml:"current_week"`
-	StartWeek   int        `xml:"start_week"`
-	EndWeek     int        `xml:"end_week"`
-	IsFinished  bool       `xml:"is_finished"`
-	Standings   []Team     `xml:"standings>teams>team"`
-	Scoreboard  Scoreboard `xml:"scoreboard"`
-	Settings    Settings   `xml:"settings"`
+	LeagueKey    string        `xml:"league_key"`
+	LeagueID     uint64        `xml:"league_id"`
+	Name         string        `xml:"name"`
+	URL          string        `xml:"url"`
+	Players      []Player      `xml:"players>player"`
+	Teams        []Team        `xml:"teams>team"`
+	DraftStatus  string        `xml:"draft_status"`
+	CurrentWeek  int           `xml:"current_week"`
+	StartWeek    int           `xml:"start_week"`
+	EndWeek      int           `xml:"end_week"`
+	IsFinished   bool          `xml:"is_finished"`
+	Standings    []Team        `xml:"standings>teams>team"`
+	Scoreboard   Scoreboard    `xml:"scoreboard"`
+	Settings     Settings      `xml:"settings"`
+	Transactions []Transaction `xml:"transactions>transaction"`
+	DraftResults []DraftResult `xml:"draft_results>draft_result"`
 }
 
 // A Team is a participant in exactly one league.
@@ -256,14 +432,72 @@ type Team struct {
 	TeamProjectedPoints   Points        `xml:"team_projected_points"`
 	TeamStandings         TeamStandings `xml:"team_standings"`
 	Players               []Player      `xml:"players>player"`
+	DraftResults          []DraftResult `xml:"draft_results>draft_result"`
+	TeamStats             []Stat        `xml:"team_stats>stats>stat"`
+	WinProbability        float64       `xml:"win_probability"`
 }
 
 // Settings describes how a league is configured
 type Settings struct {
-	DraftType        string `xml:"draft_type"`
-	ScoringType      string `xml:"scoring_type"`
-	UsesPlayoff      bool   `xml:"uses_playoff"`
-	PlayoffStartWeek int    `xml:"playoff_start_week"`
+	DraftType                  string           `xml:"draft_type"`
+	IsAuctionDraft             bool             `xml:"is_auction_draft"`
+	ScoringType                string           `xml:"scoring_type"`
+	UsesPlayoff                bool             `xml:"uses_playoff"`
+	PlayoffStartWeek           int              `xml:"playoff_start_week"`
+	NumPlayoffTeams            int              `xml:"num_playoff_teams"`
+	HasPlayoffConsolationGames bool             `xml:"has_playoff_consolation_games"`
+	NumPlayoffConsolationTeams int              `xml:"num_playoff_consolation_teams"`
+	UsesPlayoffReseeding       bool             `xml:"uses_playoff_reseeding"`
+	MaxTeams                   int              `xml:"max_teams"`
+	WaiverType                 string           `xml:"waiver_type"`
+	WaiverRule                 string           `xml:"waiver_rule"`
+	WaiverTime                 int              `xml:"waiver_time"`
+	UsesFaab                   bool             `xml:"uses_faab"`
+	FaabBudget                 int              `xml:"faab_budget"`
+	PostDraftPlayers           string           `xml:"post_draft_players"`
+	MaxWeeklyAdds              int              `xml:"max_weekly_adds"`
+	TradeEndDate               string           `xml:"trade_end_date"`
+	TradeRatifyType            string           `xml:"trade_ratify_type"`
+	TradeRejectTime            int              `xml:"trade_reject_time"`
+	CanTradeDraftPicks         bool             `xml:"can_trade_draft_picks"`
+	PlayerPool                 string           `xml:"player_pool"`
+	CantCutList                string           `xml:"cant_cut_list"`
+	UsesKeepers                bool             `xml:"uses_keepers"`
+	NumKeepers                 int              `xml:"num_keepers"`
+	RosterPositions            []RosterPosition `xml:"roster_positions>roster_position"`
+	StatCategories             []StatCategory   `xml:"stat_categories>stats>stat"`
+	StatModifiers              []StatModifier   `xml:"stat_modifiers>stats>stat"`
+	Divisions                  []Division       `xml:"divisions>division"`
+}
+
+// RosterPosition is a position on the rosters of teams in a league and the
+// number of players that can fill it.
+type RosterPosition struct {
+	Position           string `xml:"position"`
+	PositionType       string `xml:"position_type"`
+	Count              int    `xml:"count"`
+	IsStartingPosition bool   `xml:"is_starting_position"`
+}
+
+// StatModifier is the number of fantasy points a player earns for each unit
+// of a stat in a points league, along with any bonuses for reaching a total.
+type StatModifier struct {
+	StatID  int         `xml:"stat_id"`
+	Value   float64     `xml:"value"`
+	Bonuses []StatBonus `xml:"bonuses>bonus"`
+}
+
+// StatBonus is the number of extra fantasy points a player earns when a stat
+// reaches the target value.
+type StatBonus struct {
+	Target float64 `xml:"target"`
+	Points float64 `xml:"points"`
+}
+
+// A Division is a group of teams within a league.
+type Division struct {
+	DivisionID int    `xml:"division_id"`
+	Name       string `xml:"name"`
 }
 
 // Scoreboard represents the matchups that occurred for one or more weeks.
@@ -272,18 +506,36 @@ type Scoreboard struct {
 	Matchups []Matchup `xml:"matchups>matchup"`
 }
 
-// A Roster is the set of players belonging to one team for a given week.
+// A Roster is the set of players belonging to one team for a given week or,
+// in sports with daily lineups, a given date.
 type Roster struct {
 	CoverageType string   `xml:"coverage_type"`
 	Players      []Player `xml:"players>player"`
 	Week         int      `xml:"week"`
+	Date         string   `xml:"date"`
 }
 
 // A Matchup is a collection of teams paired against one another for a given
 // week.
 type Matchup struct {
-	Week  int    `xml:"week"`
-	Teams []Team `xml:"teams>team"`
+	Week          int          `xml:"week"`
+	WeekStart     string       `xml:"week_start"`
+	WeekEnd       string       `xml:"week_end"`
+	Status        string       `xml:"status"`
+	IsPlayoffs    bool         `xml:"is_playoffs"`
+	IsConsolation bool         `xml:"is_consolation"`
+	IsTied        bool         `xml:"is_tied"`
+	WinnerTeamKey string       `xml:"winner_team_key"`
+	StatWinners   []StatWinner `xml:"stat_winners>stat_winner"`
+	Teams         []Team       `xml:"teams>team"`
+}
+
+// StatWinner is the result of a single stat category in a matchup between
+// teams in a head-to-head categories league.
+type StatWinner struct {
+	StatID        int    `xml:"stat_id"`
+	WinnerTeamKey string `xml:"winner_team_key"`
+	IsTied        bool   `xml:"is_tied"`
 }
 
 // A Manager is a user in change of a given team.
@@ -300,8 +552,10 @@ type Points struct {
 	CoverageType string `xml:"coverage_type"`
 	Season       string `xml:"season"`
 	Week         int    `xml:"week"`
-	Total        float64
-	TotalStr     string `xml:"total"`
+	Date         string `xml:"date"`
+	// Total is parsed from TotalStr when the points are decoded
+	Total    float64
+	TotalStr string `xml:"total"`
 }
 
 // Record is the number of wins, losses, and ties for a given team in their
@@ -314,6 +568,7 @@ type Record struct {
 
 // TeamStandings describes how a single Team ranks in their league.
 type TeamStandings struct {
+	// Rank is parsed from RankStr when the standings are decoded
 	Rank          int
 	RankStr       string  `xml:"rank"`
 	Record        Record  `xml:"outcome_totals"`
@@ -336,12 +591,16 @@ type Player struct {
 	ElligiblePositions []string         `xml:"elligible_positions>position"`
 	SelectedPosition   SelectedPosition `xml:"selected_position"`
 	PlayerPoints       Points           `xml:"player_points"`
+	Stats              []Stat           `xml:"player_stats>stats>stat"`
+	TransactionData    TransactionData  `xml:"transaction_data"`
 }
 
-// SelectedPosition is the position chosen for a Player for a given week.
+// SelectedPosition is the position chosen for a Player for a given week or
+// date, depending on CoverageType.
 type SelectedPosition struct {
 	CoverageType string `xml:"coverage_type"`
 	Week         int    `xml:"week"`
+	Date         string `xml:"date"`
 	Position     string `xml:"position"`
 }
 
@@ -358,28 +617,46 @@ type Name struct {
 // NewCachedClient creates a new fantasy client that checks and updates the
 // given Cache when retrieving fantasy content.
 //
-// See NewLRUCache
-func NewCachedClient(cache Cache, client HTTPClient) *Client {
-	return &Client{
-		Provider: &cachedContentProvider{
-			delegate: NewClient(client).Provider,
-			cache:    cache,
-		},
-	}
+// See NewLRUCache, NewDiskCache, NewPolicyCache, and WithCache
+func NewCachedClient(cache Cache, client HTTPClient, options ...Option) *Client {
+	return NewClient(client, append(options, WithCache(cache))...)
 }
 
 // NewClient creates a Client that to communicate with the Yahoo fantasy
 // sports API. See the package level documentation for one way to create a
 // http.Client that can authenticate with Yahoo's APIs which can be passed
 // in here.
-func NewClient(c HTTPClient) *Client {
+//
+// Options can be given to further configure the returned client.
+func NewClient(c HTTPClient, options ...Option) *Client {
+	o := newClientOptions(options)
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		c = o.middleware[i](c)
+	}
+
+	apiClient := &countingHTTPApiClient{
+		client:       c,
+		requestCount: 0,
+		retryPolicy:  &o.retryPolicy,
+		rateLimiter:  o.rateLimiter,
+		userAgent:    o.userAgent,
+		timeout:      o.timeout,
+	}
+	var provider ContentProvider = &xmlContentProvider{client: apiClient}
+	if o.jsonFormat {
+		provider = &jsonContentProvider{client: apiClient}
+	}
+	if o.cache != nil {
+		provider = &cachedContentProvider{
+			delegate: provider,
+			cache:    o.cache,
+			user:     o.cacheUser,
+		}
+	}
+
 	return &Client{
-		Provider: &xmlContentProvider{
-			client: &countingHTTPApiClient{
-				client:       c,
-				requestCount: 0,
-			},
-		},
+		Provider: provider,
+		BaseURL:  o.baseURL,
 	}
 }
 
@@ -396,13 +673,17 @@ func GetConsumer(clientID string, clientSecret string) *oauth.Consumer {
 }
 
 // GetOAuth2Config generates an OAuth 2 configuration for the Yahoo fantasy
-// sports API
-func GetOAuth2Config(clientID string, clientSecret string, redirectURL string) *oauth2.Config {
+// sports API. Only ReadScope is requested unless other scopes are given, for
+// example ReadWriteScope to be able to set lineups or make transactions.
+func GetOAuth2Config(clientID string, clientSecret string, redirectURL string, scopes ...string) *oauth2.Config {
+	if len(scopes) == 0 {
+		scopes = []string{ReadScope}
+	}
 	return &oauth2.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		RedirectURL:  redirectURL,
-		Scopes:       []string{"fspt-r"},
+		Scopes:       scopes,
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  YahooOauth2AuthURL,
 			TokenURL: YahooOauth2TokenURL,
@@ -416,6 +697,22 @@ func (c *Client) RequestCount() int {
 	return c.Provider.RequestCount()
 }
 
+// baseURL returns the base URL used to build requests to the API.
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return YahooBaseURL
+	}
+	return strings.TrimSuffix(c.BaseURL, "/")
+}
+
+// RequestCountByResource returns the amount of requests made to the Yahoo API
+// on behalf of the application represented by this Client, keyed by the type
+// of resource requested. For example, a request for
+// ".../league/223.l.431/scoreboard" is counted under "league".
+func (c *Client) RequestCountByResource() map[string]int {
+	return c.Provider.RequestCountByResource()
+}
+
 //
 // Cache
 //
@@ -441,12 +738,22 @@ func NewLRUCache(
 // given time. The content for that URL will be available by LRUCache.Get from
 // the given 'time' up to 'time + l.Duration'
 func (l *LRUCache) Set(url string, time time.Time, content *FantasyContent) {
-	l.Cache.Set(l.getKey(url, time), &LRUCacheValue{content: content})
+	l.SetWithTTL(url, time, content, 0)
+}
+
+// SetWithTTL specifies that the given content was retrieved for the given URL
+// at the given time and is valid for the given TTL instead of l.Duration. A
+// TTL of zero uses l.Duration, and NoExpiration keeps the content until it is
+// evicted from the LRU cache.
+func (l *LRUCache) SetWithTTL(url string, time time.Time, content *FantasyContent, ttl time.Duration) {
+	l.Cache.Set(l.getKey(url), &LRUCacheValue{content: content, time: time, ttl: ttl})
 }
 
-// Get the content for the given URL at the given time.
+// Get the content for the given URL at the given time. Content that has
+// expired by the given time is removed from the cache.
 func (l *LRUCache) Get(url string, time time.Time) (content *FantasyContent, ok bool) {
-	value, ok := l.Cache.Get(l.getKey(url, time))
+	key := l.getKey(url)
+	value, ok := l.Cache.Get(key)
 	if !ok {
 		return nil, ok
 	}
@@ -454,25 +761,43 @@ func (l *LRUCache) Get(url string, time time.Time) (content *FantasyContent, ok
 	if !ok {
 		return nil, ok
 	}
+	if l.isExpired(lruCacheValue, time) {
+		l.Cache.Delete(key)
+		return nil, false
+	}
+	if time.Before(lruCacheValue.time) {
+		return nil, false
+	}
 	return lruCacheValue.content, true
 }
 
+// isExpired returns whether the cached value is no longer valid at the given
+// time.
+func (l *LRUCache) isExpired(value *LRUCacheValue, time time.Time) bool {
+	ttl := value.ttl
+	if ttl == 0 {
+		ttl = l.Duration
+	}
+	if ttl < 0 {
+		return false
+	}
+	return !time.Before(value.time.Add(ttl))
+}
+
 // getKey converts a base key to a key that is unique for the client of the
-// LRUCache and the current time period.
+// LRUCache.
 //
 // The created keys have the following format:
 //
-//    <client-id>:<originalKey>:<period>
+//    <client-id>:<originalKey>
 //
-// Given a client with ID "client-id-01", original key of "key-01", a current
-// time of "08/17/2014 1:21pm", and a maximum cache duration of 1 hour, this
+// Given a client with ID "client-id-01" and original key of "key-01", this
 // will generate the following key:
 //
-//    client-id-01:key-01:391189
+//    client-id-01:key-01
 //
-func (l *LRUCache) getKey(originalKey string, time time.Time) string {
-	period := time.Unix() / l.DurationSeconds
-	return fmt.Sprintf("%s:%s:%d", l.ClientID, originalKey, period)
+func (l *LRUCache) getKey(originalKey string) string {
+	return fmt.Sprintf("%s:%s", l.ClientID, originalKey)
 }
 
 // Size always returns '1'. All LRU cache values have the same size, meaning
@@ -486,29 +811,187 @@ func (v *LRUCacheValue) Size() int {
 // ContentProvider
 //
 
-func (p *cachedContentProvider) Get(url string) (*FantasyContent, error) {
+func (p *cachedContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	key, ok := p.cacheKey(url)
+	if !ok {
+		return p.delegate.Get(ctx, url)
+	}
+
 	currentTime := time.Now()
-	content, ok := p.cache.Get(url, currentTime)
+	content, ok := p.cache.Get(key, currentTime)
 	if !ok {
-		content, err := p.delegate.Get(url)
-		if err == nil {
-			p.cache.Set(url, currentTime, content)
-		}
-		return content, err
+		return p.getShared(ctx, url, key, currentTime)
 	}
 	return content, nil
 }
 
+// cacheKey returns the key used to cache the content for the given URL. When
+// the provider has a user, the key is the URL with the user added as its
+// fragment, for example:
+//
+//    https://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431#user=ABC123
+//
+// Content for login-relative URLs, such as ".../users;use_login=1/games",
+// depends on who is logged in. It is not cached when there is no user, nor is
+// any other content with teams, since Yahoo flags the teams and managers of
+// the logged in user.
+func (p *cachedContentProvider) cacheKey(url string) (string, bool) {
+	if p.user == "" {
+		return url, !isLoginRelative(url)
+	}
+	return url + "#user=" + neturl.QueryEscape(p.user), true
+}
+
+// isLoginRelative returns whether the content for the URL depends on the
+// logged in user.
+func isLoginRelative(url string) bool {
+	return strings.Contains(url, "use_login=1")
+}
+
+// hasTeams returns whether the content includes any teams. Yahoo flags the
+// teams and managers of the logged in user, so content with teams differs
+// between users even when none of them are flagged.
+func hasTeams(content *FantasyContent) bool {
+	if content.Team.TeamKey != "" || len(content.Team.Managers) > 0 ||
+		leagueHasTeams(&content.League) {
+		return true
+	}
+	for _, user := range content.Users {
+		if gamesHaveTeams(user.Games) {
+			return true
+		}
+	}
+	return gamesHaveTeams(content.Games)
+}
+
+// gamesHaveTeams returns whether any league in the games includes teams.
+func gamesHaveTeams(games []Game) bool {
+	for _, game := range games {
+		for i := range game.Leagues {
+			if leagueHasTeams(&game.Leagues[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// leagueHasTeams returns whether the league includes any teams, standings, or
+// matchups between teams.
+func leagueHasTeams(league *League) bool {
+	if len(league.Teams) > 0 || len(league.Standings) > 0 {
+		return true
+	}
+	for _, matchup := range league.Scoreboard.Matchups {
+		if len(matchup.Teams) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getShared requests content from the delegate, joining a request for the
+// same cache key that is already in flight if there is one. Each caller stops
+// waiting when its own context is done, while the request itself is only
+// canceled once every caller has stopped waiting.
+func (p *cachedContentProvider) getShared(ctx context.Context, url string, key string, currentTime time.Time) (*FantasyContent, error) {
+	p.mutex.Lock()
+	request, ok := p.inFlight[key]
+	if !ok {
+		requestCtx, cancel := context.WithCancel(detachedContext{ctx})
+		request = &sharedRequest{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		if p.inFlight == nil {
+			p.inFlight = make(map[string]*sharedRequest)
+		}
+		p.inFlight[key] = request
+		go p.fetch(requestCtx, url, key, currentTime, request)
+	}
+	request.waiters++
+	p.mutex.Unlock()
+
+	select {
+	case <-request.done:
+		return request.content, request.err
+	case <-ctx.Done():
+		p.mutex.Lock()
+		request.waiters--
+		if request.waiters == 0 {
+			// Later callers must start a new request instead of joining
+			// the canceled one
+			if p.inFlight[key] == request {
+				delete(p.inFlight, key)
+			}
+			request.cancel()
+		}
+		p.mutex.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// fetch completes a shared request using the delegate and caches the content
+// under the given key if it was retrieved successfully.
+func (p *cachedContentProvider) fetch(ctx context.Context, url string, key string, currentTime time.Time, request *sharedRequest) {
+	defer request.cancel()
+
+	content, err := p.delegate.Get(ctx, url)
+	if err == nil && (p.user != "" || !hasTeams(content)) {
+		p.cache.Set(key, currentTime, content)
+	}
+
+	p.mutex.Lock()
+	if p.inFlight[key] == request {
+		delete(p.inFlight, key)
+	}
+	p.mutex.Unlock()
+
+	request.content = content
+	request.err = err
+	close(request.done)
+}
+
+// Send never uses the cache.
+func (p *cachedContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	return p.delegate.Send(ctx, method, url, body)
+}
+
 func (p *cachedContentProvider) RequestCount() int {
 	return p.delegate.RequestCount()
 }
 
-func (p *xmlContentProvider) Get(url string) (*FantasyContent, error) {
-	response, err := p.client.Get(url)
+func (p *cachedContentProvider) RequestCountByResource() map[string]int {
+	return p.delegate.RequestCountByResource()
+}
+
+func (p *xmlContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	response, err := p.client.Get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+	return readContent(response, false, xml.Unmarshal)
+}
+
+// Send allows an empty response, since Yahoo does not always describe the
+// content that was changed.
+func (p *xmlContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	response, err := p.client.Send(ctx, method, url, body)
 
 	if err != nil {
 		return nil, err
 	}
+	return readContent(response, true, xml.Unmarshal)
+}
+
+// readContent parses the fantasy content from the body of the response using
+// the given unmarshal function and closes the body.
+func readContent(
+	response *http.Response,
+	allowEmpty bool,
+	unmarshal func([]byte, interface{}) error) (*FantasyContent, error) {
+
 	defer response.Body.Close()
 
 	bits, err := ioutil.ReadAll(response.Body)
@@ -517,92 +1000,175 @@ func (p *xmlContentProvider) Get(url string) (*FantasyContent, error) {
 	}
 
 	var content FantasyContent
-	err = xml.Unmarshal(bits, &content)
+	if allowEmpty && len(bytes.TrimSpace(bits)) == 0 {
+		return &content, nil
+	}
+
+	err = unmarshal(bits, &content)
 	if err != nil {
 		return nil, err
 	}
 
-	return fixContent(&content), nil
+	return &content, nil
 }
 
-// fixContent updates the fantasy data with content that can't be unmarshalled
-// directly from XML
-func fixContent(c *FantasyContent) *FantasyContent {
-	fixTeam(&c.Team)
-	for i := range c.League.Teams {
-		fixTeam(&c.League.Teams[i])
-	}
-	for i := range c.League.Standings {
-		fixTeam(&c.League.Standings[i])
-	}
-	for i := range c.League.Players {
-		fixPoints(&c.League.Players[i].PlayerPoints)
+// UnmarshalXML decodes points and parses their total. Yahoo leaves the total
+// empty when no points have been scored, which is decoded as zero.
+func (p *Points) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type points Points
+	var decoded points
+	if err := d.DecodeElement(&decoded, &start); err != nil {
+		return err
 	}
-	for i := range c.League.Scoreboard.Matchups {
-		fixTeam(&c.League.Scoreboard.Matchups[i].Teams[0])
-		fixTeam(&c.League.Scoreboard.Matchups[i].Teams[1])
+
+	total, err := parseFloat(decoded.TotalStr)
+	if err != nil {
+		return fmt.Errorf("invalid total points %q: %w", decoded.TotalStr, err)
 	}
-	return c
+	decoded.Total = total
+	*p = Points(decoded)
+	return nil
 }
 
-func fixTeam(t *Team) {
-	fixPoints(&t.TeamPoints)
-	fixPoints(&t.TeamProjectedPoints)
-	for i := range t.Roster.Players {
-		fixPoints(&t.Roster.Players[i].PlayerPoints)
+// UnmarshalXML decodes team standings and parses their rank. Yahoo leaves the
+// rank empty before any games have been played, which is decoded as zero.
+func (t *TeamStandings) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type teamStandings TeamStandings
+	var decoded teamStandings
+	if err := d.DecodeElement(&decoded, &start); err != nil {
+		return err
 	}
-	for i := range t.Players {
-		fixPoints(&t.Players[i].PlayerPoints)
-	}
-	for i := range t.Matchups {
-		fixTeam(&t.Matchups[i].Teams[0])
-		fixTeam(&t.Matchups[i].Teams[1])
+
+	rank, err := parseInt(decoded.RankStr)
+	if err != nil {
+		return fmt.Errorf("invalid team rank %q: %w", decoded.RankStr, err)
 	}
-	fixRank(&t.TeamStandings)
+	decoded.Rank = rank
+	*t = TeamStandings(decoded)
+	return nil
 }
 
-func fixRank(t *TeamStandings) {
-	if t.RankStr != "" {
-		rank, err := strconv.ParseInt(t.RankStr, 10, 64)
-		if err == nil {
-			t.Rank = int(rank)
-		}
+// parseFloat parses a decimal number, treating an empty value as zero.
+func parseFloat(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
 	}
+	return strconv.ParseFloat(value, 64)
 }
 
-func fixPoints(p *Points) {
-	if p.TotalStr != "" {
-		total, err := strconv.ParseFloat(p.TotalStr, 64)
-		if err == nil {
-			p.Total = total
-		}
+// parseInt parses an integer, treating an empty value as zero.
+func parseInt(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
 	}
+	return strconv.Atoi(value)
 }
 
 func (p *xmlContentProvider) RequestCount() int {
 	return p.client.RequestCount()
 }
 
+func (p *xmlContentProvider) RequestCountByResource() map[string]int {
+	return p.client.RequestCountByResource()
+}
+
 //
 // httpAPIClient
 //
 
-// Get returns the HTTP response of a GET request to the given URL.
-func (o *countingHTTPApiClient) Get(url string) (*http.Response, error) {
-	o.requestCount++
-	response, err := o.client.Get(url)
+// Get returns the HTTP response of a GET request to the given URL. The
+// request will be canceled if the given context is done. An *APIError is
+// returned if the API responds with an error status code.
+//
+// Failed requests are retried according to the client's RetryPolicy.
+func (o *countingHTTPApiClient) Get(ctx context.Context, url string) (*http.Response, error) {
+	return o.Send(ctx, http.MethodGet, url, nil)
+}
+
+// Send returns the HTTP response of a request to the given URL using the
+// given method and XML body. Errors are returned the same way as Get.
+//
+// Requests that modify content, such as POST or PUT, are only retried when
+// Yahoo rate limited them, since other failures may have been processed.
+func (o *countingHTTPApiClient) Send(ctx context.Context, method string, url string, body []byte) (*http.Response, error) {
+	policy := o.retryPolicy
+	if policy == nil {
+		defaultPolicy := DefaultRetryPolicy()
+		policy = &defaultPolicy
+	}
+
+	for attempt := 1; ; attempt++ {
+		request, err := o.newRequest(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := o.do(url, request)
+		if err == nil ||
+			attempt >= policy.attempts() ||
+			!policy.shouldRetry(err) ||
+			(method != http.MethodGet && !errors.Is(err, ErrRateLimited)) {
+			return response, err
+		}
+
+		if sleepErr := sleepContext(ctx, policy.delay(attempt, err)); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
+}
+
+// newRequest creates a request for a single attempt, since the body of a
+// request can only be read once.
+func (o *countingHTTPApiClient) newRequest(ctx context.Context, method string, url string, body []byte) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/xml")
+	}
+	if o.userAgent != "" {
+		request.Header.Set("User-Agent", o.userAgent)
+	}
+	return request, nil
+}
+
+// do makes a single attempt of the given request after waiting for the rate
+// limiter.
+func (o *countingHTTPApiClient) do(url string, request *http.Request) (*http.Response, error) {
+	if o.rateLimiter != nil {
+		if err := o.rateLimiter.Wait(request.Context()); err != nil {
+			return nil, err
+		}
+	}
 
-	// Known issue where "consumer_key_unknown" is returned for valid
-	// consumer keys. If this happens, try re-requesting the content a few
-	// times to see if it fixes itself
-	//
-	// See https://developer.yahoo.com/forum/OAuth-General-Discussion-YDN-SDKs/oauth-problem-consumer-key-unknown-/1375188859720-5cea9bdb-0642-4606-9fd5-c5f369112959
-	for attempts := 0; attempts < 4 &&
-		err != nil &&
-		strings.Contains(err.Error(), "consumer_key_unknown"); attempts++ {
+	var cancel context.CancelFunc
+	if o.timeout > 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(request.Context(), o.timeout)
+		request = request.WithContext(ctx)
+	}
 
-		o.requestCount++
-		response, err = o.client.Get(url)
+	o.countRequest(url)
+	response, err := o.client.Do(request)
+	if cancel != nil {
+		if err != nil || response == nil || response.Body == nil {
+			cancel()
+		} else {
+			// Reading the body is part of the request, so the timeout must
+			// not be canceled until the body is closed.
+			response.Body = &cancelOnCloseBody{
+				ReadCloser: response.Body,
+				cancel:     cancel,
+			}
+		}
 	}
 
 	if err != nil &&
@@ -612,13 +1178,76 @@ func (o *countingHTTPApiClient) Get(url string) (*http.Response, error) {
 		err = ErrAccessDenied
 	}
 
+	if err == nil {
+		err = checkResponse(url, response)
+		if err != nil {
+			response = nil
+		}
+	}
+
 	return response, err
 }
 
+// cancelOnCloseBody cancels the context of a request once its response body
+// is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// countRequest records that a request was made for the given URL.
+func (o *countingHTTPApiClient) countRequest(url string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.requestCount++
+	if o.resourceCounts == nil {
+		o.resourceCounts = make(map[string]int)
+	}
+	o.resourceCounts[resourceType(url)]++
+}
+
 func (o *countingHTTPApiClient) RequestCount() int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 	return o.requestCount
 }
 
+func (o *countingHTTPApiClient) RequestCountByResource() map[string]int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	counts := make(map[string]int, len(o.resourceCounts))
+	for resource, count := range o.resourceCounts {
+		counts[resource] = count
+	}
+	return counts
+}
+
+// resourceType returns the first resource or collection named in the path of
+// the given API URL, ignoring any parameters. If no known resource is found,
+// "other" is returned.
+func resourceType(rawURL string) string {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return otherResource
+	}
+
+	for _, segment := range strings.Split(parsed.Path, "/") {
+		if i := strings.Index(segment, ";"); i >= 0 {
+			segment = segment[:i]
+		}
+		if apiResources[segment] {
+			return segment
+		}
+	}
+	return otherResource
+}
+
 //
 // Yahoo interface
 //
@@ -627,23 +1256,70 @@ func (o *countingHTTPApiClient) RequestCount() int {
 //
 // See http://developer.yahoo.com/fantasysports/guide/ for more information
 func (c *Client) GetFantasyContent(url string) (*FantasyContent, error) {
-	return c.Provider.Get(url)
+	return c.GetFantasyContentContext(context.Background(), url)
+}
+
+// GetFantasyContentContext directly access Yahoo fantasy resources. The given
+// context can be used to cancel the request or put a deadline on it.
+//
+// See http://developer.yahoo.com/fantasysports/guide/ for more information
+func (c *Client) GetFantasyContentContext(ctx context.Context, url string) (*FantasyContent, error) {
+	return c.Provider.Get(ctx, url)
+}
+
+// SendFantasyContentContext directly modifies Yahoo fantasy resources by
+// sending the XML body to the URL with the given HTTP method. The client must
+// be authorized with ReadWriteScope.
+//
+// See http://developer.yahoo.com/fantasysports/guide/ for more information
+func (c *Client) SendFantasyContentContext(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	return c.Provider.Send(ctx, method, url, body)
 }
 
 //
 // Convenience functions
 //
 
-// GetUserLeagues returns a list of the current user's leagues for the given
-// year.
+// GetUserLeagues returns a list of the current user's fantasy football
+// leagues for the given year.
+//
+// See GetUserLeaguesForGame for other sports.
 func (c *Client) GetUserLeagues(year string) ([]League, error) {
-	yearKey, ok := YearKeys[year]
-	if !ok {
-		return nil, fmt.Errorf("data not available for year=%s", year)
+	return c.GetUserLeaguesContext(context.Background(), year)
+}
+
+// GetUserLeaguesContext returns a list of the current user's fantasy football
+// leagues for the given year using the given context for the API request.
+func (c *Client) GetUserLeaguesContext(ctx context.Context, year string) ([]League, error) {
+	if year == NflGameKey {
+		year = ""
+	}
+	return c.GetUserLeaguesForGameContext(ctx, NflGameKey, year)
+}
+
+// GetUserLeaguesForGame returns a list of the current user's leagues in the
+// game with the given code, e.g. MlbGameKey, for the given season. Leagues for
+// the current season are returned when the season is empty.
+func (c *Client) GetUserLeaguesForGame(gameCode string, season string) ([]League, error) {
+	return c.GetUserLeaguesForGameContext(context.Background(), gameCode, season)
+}
+
+// GetUserLeaguesForGameContext returns a list of the current user's leagues
+// in the game with the given code for the given season using the given
+// context for the API requests.
+func (c *Client) GetUserLeaguesForGameContext(ctx context.Context, gameCode string, season string) ([]League, error) {
+	yearKey := gameCode
+	if season != "" {
+		var err error
+		yearKey, err = c.GetGameKeyContext(ctx, gameCode, season)
+		if err != nil {
+			return nil, err
+		}
 	}
-	content, err := c.GetFantasyContent(
+	content, err := c.GetFantasyContentContext(
+		ctx,
 		fmt.Sprintf("%s/users;use_login=1/games;game_keys=%s/leagues",
-			YahooBaseURL,
+			c.baseURL(),
 			yearKey))
 
 	if err != nil {
@@ -663,8 +1339,103 @@ func (c *Client) GetUserLeagues(year string) ([]League, error) {
 }
 
 // GetPlayersStats returns a list of Players containing their stats for the
-// given week in the given year.
+// given week in the given year. Any number of players can be given; if some of
+// the requests needed to get their stats fail, the players that could be
+// retrieved are returned along with the error.
 func (c *Client) GetPlayersStats(leagueKey string, week int, players []Player) ([]Player, error) {
+	return c.GetPlayersStatsContext(context.Background(), leagueKey, week, players)
+}
+
+// GetPlayersStatsContext returns a list of Players containing their stats for
+// the given week in the given year using the given context for the API
+// request.
+func (c *Client) GetPlayersStatsContext(ctx context.Context, leagueKey string, week int, players []Player) ([]Player, error) {
+	return c.getPlayersStats(
+		ctx,
+		leagueKey,
+		StatsPeriod{Type: StatsTypeWeek, Week: week},
+		players)
+}
+
+// GetPlayersStatsForDate returns a list of Players containing their stats for
+// the given date. Use this for sports with daily lineups, such as baseball.
+func (c *Client) GetPlayersStatsForDate(leagueKey string, date time.Time, players []Player) ([]Player, error) {
+	return c.GetPlayersStatsForDateContext(context.Background(), leagueKey, date, players)
+}
+
+// GetPlayersStatsForDateContext returns a list of Players containing their
+// stats for the given date using the given context for the API request.
+func (c *Client) GetPlayersStatsForDateContext(ctx context.Context, leagueKey string, date time.Time, players []Player) ([]Player, error) {
+	return c.getPlayersStats(
+		ctx,
+		leagueKey,
+		StatsPeriod{Type: StatsTypeDate, Date: date},
+		players)
+}
+
+// getPlayersStats returns a list of Players containing their stats for the
+// given period.
+//
+// Yahoo limits the number of players in a single request, so players are
+// requested in batches of at most maxPlayersPerPage, up to
+// maxConcurrentBatches at a time. If any batch fails, the players from the
+// other batches are returned along with the error.
+func (c *Client) getPlayersStats(ctx context.Context, leagueKey string, period StatsPeriod, players []Player) ([]Player, error) {
+	params := period.params()
+	if len(players) <= maxPlayersPerPage {
+		return c.getBatchStats(ctx, leagueKey, params, players)
+	}
+
+	var batches [][]Player
+	for start := 0; start < len(players); start += maxPlayersPerPage {
+		end := start + maxPlayersPerPage
+		if end > len(players) {
+			end = len(players)
+		}
+		batches = append(batches, players[start:end])
+	}
+
+	results := make([][]Player, len(batches))
+	errs := make([]error, len(batches))
+	semaphore := make(chan struct{}, maxConcurrentBatches)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []Player) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			results[i], errs[i] = c.getBatchStats(ctx, leagueKey, params, batch)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var merged []Player
+	var firstErr error
+	failed := 0
+	for i := range batches {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			failed++
+			continue
+		}
+		merged = append(merged, results[i]...)
+	}
+
+	if firstErr != nil {
+		return merged, fmt.Errorf("%d of %d requests for player stats failed: %w",
+			failed,
+			len(batches),
+			firstErr)
+	}
+	return merged, nil
+}
+
+// getBatchStats returns a list of Players containing their stats using a
+// single request.
+func (c *Client) getBatchStats(ctx context.Context, leagueKey string, params string, players []Player) ([]Player, error) {
 	playerKeys := ""
 	for index, player := range players {
 		if index != 0 {
@@ -673,12 +1444,13 @@ func (c *Client) GetPlayersStats(leagueKey string, week int, players []Player) (
 		playerKeys += player.PlayerKey
 	}
 
-	content, err := c.GetFantasyContent(
-		fmt.Sprintf("%s/league/%s/players;player_keys=%s/stats;type=week;week=%d",
-			YahooBaseURL,
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/league/%s/players;player_keys=%s/stats;%s",
+			c.baseURL(),
 			leagueKey,
 			playerKeys,
-			week))
+			params))
 
 	if err != nil {
 		return nil, err
@@ -688,11 +1460,36 @@ func (c *Client) GetPlayersStats(leagueKey string, week int, players []Player) (
 
 // GetTeamRoster returns a team's roster for the given week.
 func (c *Client) GetTeamRoster(teamKey string, week int) ([]Player, error) {
-	content, err := c.GetFantasyContent(
-		fmt.Sprintf("%s/team/%s/roster;week=%d",
-			YahooBaseURL,
+	return c.GetTeamRosterContext(context.Background(), teamKey, week)
+}
+
+// GetTeamRosterContext returns a team's roster for the given week using the
+// given context for the API request.
+func (c *Client) GetTeamRosterContext(ctx context.Context, teamKey string, week int) ([]Player, error) {
+	return c.getTeamRoster(ctx, teamKey, fmt.Sprintf("week=%d", week))
+}
+
+// GetTeamRosterForDate returns a team's roster for the given date. Use this
+// for sports with daily lineups, such as baseball.
+func (c *Client) GetTeamRosterForDate(teamKey string, date time.Time) ([]Player, error) {
+	return c.GetTeamRosterForDateContext(context.Background(), teamKey, date)
+}
+
+// GetTeamRosterForDateContext returns a team's roster for the given date
+// using the given context for the API request.
+func (c *Client) GetTeamRosterForDateContext(ctx context.Context, teamKey string, date time.Time) ([]Player, error) {
+	return c.getTeamRoster(ctx, teamKey, "date="+date.Format(DateFormat))
+}
+
+// getTeamRoster returns a team's roster for the period described by the given
+// roster parameters.
+func (c *Client) getTeamRoster(ctx context.Context, teamKey string, params string) ([]Player, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/team/%s/roster;%s",
+			c.baseURL(),
 			teamKey,
-			week))
+			params))
 	if err != nil {
 		return nil, err
 	}
@@ -702,9 +1499,16 @@ func (c *Client) GetTeamRoster(teamKey string, week int) ([]Player, error) {
 
 // GetLeagueStandings gets a league containing the current standings.
 func (c *Client) GetLeagueStandings(leagueKey string) (*League, error) {
-	content, err := c.GetFantasyContent(
+	return c.GetLeagueStandingsContext(context.Background(), leagueKey)
+}
+
+// GetLeagueStandingsContext gets a league containing the current standings
+// using the given context for the API request.
+func (c *Client) GetLeagueStandingsContext(ctx context.Context, leagueKey string) (*League, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
 		fmt.Sprintf("%s/league/%s;out=standings,settings",
-			YahooBaseURL,
+			c.baseURL(),
 			leagueKey))
 	if err != nil {
 		return nil, err
@@ -714,9 +1518,16 @@ func (c *Client) GetLeagueStandings(leagueKey string) (*League, error) {
 
 // GetAllTeamStats gets teams stats for a given week.
 func (c *Client) GetAllTeamStats(leagueKey string, week int) ([]Team, error) {
-	content, err := c.GetFantasyContent(
+	return c.GetAllTeamStatsContext(context.Background(), leagueKey, week)
+}
+
+// GetAllTeamStatsContext gets teams stats for a given week using the given
+// context for the API request.
+func (c *Client) GetAllTeamStatsContext(ctx context.Context, leagueKey string, week int) ([]Team, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
 		fmt.Sprintf("%s/league/%s/teams/stats;type=week;week=%d",
-			YahooBaseURL,
+			c.baseURL(),
 			leagueKey,
 			week))
 	if err != nil {
@@ -728,9 +1539,16 @@ func (c *Client) GetAllTeamStats(leagueKey string, week int) ([]Team, error) {
 
 // GetTeam returns all available information about the given team.
 func (c *Client) GetTeam(teamKey string) (*Team, error) {
-	content, err := c.GetFantasyContent(
+	return c.GetTeamContext(context.Background(), teamKey)
+}
+
+// GetTeamContext returns all available information about the given team
+// using the given context for the API request.
+func (c *Client) GetTeamContext(ctx context.Context, teamKey string) (*Team, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
 		fmt.Sprintf("%s/team/%s;out=stats,metadata,players,standings,roster",
-			YahooBaseURL,
+			c.baseURL(),
 			teamKey))
 	if err != nil {
 		return nil, err
@@ -742,11 +1560,19 @@ func (c *Client) GetTeam(teamKey string) (*Team, error) {
 	return &content.Team, nil
 }
 
-// GetLeagueMetadata returns the metadata associated with the given league.
+// GetLeagueMetadata returns the metadata and settings associated with the
+// given league.
 func (c *Client) GetLeagueMetadata(leagueKey string) (*League, error) {
-	content, err := c.GetFantasyContent(
-		fmt.Sprintf("%s/league/%s/metadata",
-			YahooBaseURL,
+	return c.GetLeagueMetadataContext(context.Background(), leagueKey)
+}
+
+// GetLeagueMetadataContext returns the metadata and settings associated with
+// the given league using the given context for the API request.
+func (c *Client) GetLeagueMetadataContext(ctx context.Context, leagueKey string) (*League, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/league/%s;out=settings",
+			c.baseURL(),
 			leagueKey))
 	if err != nil {
 		return nil, err
@@ -756,8 +1582,15 @@ func (c *Client) GetLeagueMetadata(leagueKey string) (*League, error) {
 
 // GetAllTeams returns all teams playing in the given league.
 func (c *Client) GetAllTeams(leagueKey string) ([]Team, error) {
-	content, err := c.GetFantasyContent(
-		fmt.Sprintf("%s/league/%s/teams", YahooBaseURL, leagueKey))
+	return c.GetAllTeamsContext(context.Background(), leagueKey)
+}
+
+// GetAllTeamsContext returns all teams playing in the given league using the
+// given context for the API request.
+func (c *Client) GetAllTeamsContext(ctx context.Context, leagueKey string) ([]Team, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/league/%s/teams", c.baseURL(), leagueKey))
 	if err != nil {
 		return nil, err
 	}
@@ -767,13 +1600,24 @@ func (c *Client) GetAllTeams(leagueKey string) ([]Team, error) {
 // GetMatchupsForWeekRange returns a list of matchups for each week in the
 // requested range.
 func (c *Client) GetMatchupsForWeekRange(leagueKey string, startWeek, endWeek int) (map[int][]Matchup, error) {
+	return c.GetMatchupsForWeekRangeContext(
+		context.Background(),
+		leagueKey,
+		startWeek,
+		endWeek)
+}
+
+// GetMatchupsForWeekRangeContext returns a list of matchups for each week in
+// the requested range using the given context for the API request.
+func (c *Client) GetMatchupsForWeekRangeContext(ctx context.Context, leagueKey string, startWeek, endWeek int) (map[int][]Matchup, error) {
 	leagueList := strconv.Itoa(startWeek)
 	for i := startWeek + 1; i <= endWeek; i++ {
 		leagueList += "," + strconv.Itoa(i)
 	}
-	content, err := c.GetFantasyContent(
+	content, err := c.GetFantasyContentContext(
+		ctx,
 		fmt.Sprintf("%s/league/%s/scoreboard;week=%s",
-			YahooBaseURL,
+			c.baseURL(),
 			leagueKey,
 			leagueList))
 	if err != nil {
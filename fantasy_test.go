@@ -1,11 +1,15 @@
 package goff
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -82,6 +86,26 @@ func TestGetOAuth2Config(t *testing.T) {
 		redirectURL != config.RedirectURL {
 		t.Fatalf("Invalid config returned: %+v", config)
 	}
+
+	if len(config.Scopes) != 1 || config.Scopes[0] != ReadScope {
+		t.Fatalf("Unexpected scopes\n\texpected: %v\n\tactual: %v",
+			[]string{ReadScope},
+			config.Scopes)
+	}
+}
+
+func TestGetOAuth2ConfigWithScopes(t *testing.T) {
+	config := GetOAuth2Config(
+		"clientID",
+		"clientSecret",
+		"http://example.com",
+		ReadWriteScope)
+
+	if len(config.Scopes) != 1 || config.Scopes[0] != ReadWriteScope {
+		t.Fatalf("Unexpected scopes\n\texpected: %v\n\tactual: %v",
+			[]string{ReadWriteScope},
+			config.Scopes)
+	}
 }
 
 //
@@ -128,10 +152,9 @@ func TestGetKey(t *testing.T) {
 	cache := NewLRUCache(clientID, duration, lruCache)
 
 	originalKey := "key"
-	time := time.Unix(1408281677, 0)
-	expectedKey := fmt.Sprintf("%s:%s:%s", clientID, originalKey, "391189")
+	expectedKey := fmt.Sprintf("%s:%s", clientID, originalKey)
 
-	key := cache.getKey(originalKey, time)
+	key := cache.getKey(originalKey)
 
 	if key != expectedKey {
 		t.Fatalf("Did not received expected key\n\texpected: %s"+
@@ -170,7 +193,7 @@ func TestGetContentOfWrongType(t *testing.T) {
 	time := time.Unix(1408281677, 0)
 	url := "http://example.com/fantasy"
 
-	cacheKey := cache.getKey(url, time)
+	cacheKey := cache.getKey(url)
 	lruCache.Set(cacheKey, mockedValue{})
 
 	content, ok := cache.Get(url, time)
@@ -192,9 +215,9 @@ func TestGetWithContent(t *testing.T) {
 	time := time.Unix(1408281677, 0)
 	url := "http://example.com/fantasy"
 
-	cacheKey := cache.getKey(url, time)
+	cacheKey := cache.getKey(url)
 	expectedContent := createLeagueList(League{LeagueKey: "123"})
-	lruCache.Set(cacheKey, &LRUCacheValue{content: expectedContent})
+	lruCache.Set(cacheKey, &LRUCacheValue{content: expectedContent, time: time})
 
 	content, ok := cache.Get(url, time)
 	if !ok {
@@ -222,7 +245,7 @@ func TestSet(t *testing.T) {
 	expectedContent := createLeagueList(League{LeagueKey: "123"})
 	cache.Set(url, time, expectedContent)
 
-	cacheKey := cache.getKey(url, time)
+	cacheKey := cache.getKey(url)
 	value, ok := lruCache.Get(cacheKey)
 	if !ok {
 		t.Fatal("Content not set in LRU cache correctly")
@@ -239,6 +262,81 @@ func TestSet(t *testing.T) {
 			expectedContent,
 			lruCacheValue.content)
 	}
+
+	if !lruCacheValue.time.Equal(time) {
+		t.Fatalf("Unexpected time in cache\n\texpected: %+v\n\t"+
+			"actual: %+v",
+			time,
+			lruCacheValue.time)
+	}
+}
+
+func TestGetExpiresDurationAfterSet(t *testing.T) {
+	duration := time.Hour
+	lruCache := lru.NewLRUCache(10, func(_ any) int64 {
+		return 1
+	})
+	cache := NewLRUCache("clientID", duration, lruCache)
+
+	// One second before the end of an hour long period since the epoch
+	setTime := time.Unix(1408283999, 0)
+	url := "http://example.com/fantasy"
+	expectedContent := createLeagueList(League{LeagueKey: "123"})
+	cache.Set(url, setTime, expectedContent)
+
+	for _, elapsed := range []time.Duration{0, time.Second, duration - time.Second} {
+		content, ok := cache.Get(url, setTime.Add(elapsed))
+		if !ok || content != expectedContent {
+			t.Fatalf("Cache did not return content %s after it was set",
+				elapsed)
+		}
+	}
+
+	content, ok := cache.Get(url, setTime.Add(duration))
+	if ok {
+		t.Fatalf("Cache returned expired content: %+v", content)
+	}
+	assertIntEquals(t, 0, lruCache.Len())
+}
+
+func TestGetBeforeSet(t *testing.T) {
+	lruCache := lru.NewLRUCache(10, func(_ any) int64 {
+		return 1
+	})
+	cache := NewLRUCache("clientID", time.Hour, lruCache)
+
+	setTime := time.Unix(1408281677, 0)
+	url := "http://example.com/fantasy"
+	cache.Set(url, setTime, createLeagueList(League{LeagueKey: "123"}))
+
+	content, ok := cache.Get(url, setTime.Add(-time.Second))
+	if ok {
+		t.Fatalf("Cache returned content before it was set: %+v", content)
+	}
+	assertIntEquals(t, 1, lruCache.Len())
+}
+
+func TestSetReplacesContent(t *testing.T) {
+	duration := time.Hour
+	lruCache := lru.NewLRUCache(10, func(_ any) int64 {
+		return 1
+	})
+	cache := NewLRUCache("clientID", duration, lruCache)
+
+	setTime := time.Unix(1408281677, 0)
+	url := "http://example.com/fantasy"
+	cache.Set(url, setTime, createLeagueList(League{LeagueKey: "123"}))
+	expectedContent := createLeagueList(League{LeagueKey: "456"})
+	cache.Set(url, setTime.Add(duration/2), expectedContent)
+
+	content, ok := cache.Get(url, setTime.Add(duration))
+	if !ok || content != expectedContent {
+		t.Fatalf("Cache did not return replaced content\n\texpected: %+v"+
+			"\n\tactual: %+v",
+			expectedContent,
+			content)
+	}
+	assertIntEquals(t, 1, lruCache.Len())
 }
 
 func TestLRUCacheValueSize(t *testing.T) {
@@ -270,7 +368,7 @@ func TestCountingHTTPClient(t *testing.T) {
 		},
 	}
 
-	response, err := client.Get("http://example.com")
+	response, err := client.Get(context.Background(), "http://example.com")
 	if err != nil {
 		t.Fatalf("error retrieving response: %s", err)
 	}
@@ -288,9 +386,10 @@ func TestCountingHTTPClientMultipleErrorsConsumerKeyUnknown(t *testing.T) {
 			Error:      errors.New("consumer_key_unknown"),
 			ErrorCount: 5,
 		},
+		retryPolicy: &RetryPolicy{MaxAttempts: 5},
 	}
 
-	_, err := client.Get("http://example.com")
+	_, err := client.Get(context.Background(), "http://example.com")
 	if err == nil {
 		t.Fatalf("no error returned from client when consumer failed")
 	}
@@ -304,9 +403,10 @@ func TestCountingHTTPClientInitialErrorConsumerKeyUnknown(t *testing.T) {
 			Error:      errors.New("consumer_key_unknown"),
 			ErrorCount: 4,
 		},
+		retryPolicy: &RetryPolicy{MaxAttempts: 5},
 	}
 
-	response, err := client.Get("http://example.com")
+	response, err := client.Get(context.Background(), "http://example.com")
 	if err != nil {
 		t.Fatalf("error retrieving response: %s", err)
 	}
@@ -325,7 +425,7 @@ func TestCountingHTTPClientError(t *testing.T) {
 		},
 	}
 
-	_, err := client.Get("http://example.com")
+	_, err := client.Get(context.Background(), "http://example.com")
 	if err == nil {
 		t.Fatalf("no error returned from client when consumer failed")
 	}
@@ -340,7 +440,7 @@ func TestCountingHTTPClientAccessDeniedError(t *testing.T) {
 		},
 	}
 
-	content, actualErr := client.Get("http://example.com")
+	content, actualErr := client.Get(context.Background(), "http://example.com")
 	if content != nil {
 		t.Fatalf("OAauth HTTP client returned unexpected content: %+v", content)
 	}
@@ -352,6 +452,142 @@ func TestCountingHTTPClientAccessDeniedError(t *testing.T) {
 	}
 }
 
+func TestCountingHTTPClientUsesContext(t *testing.T) {
+	httpClient := &mockHTTPClient{Response: &http.Response{}}
+	client := &countingHTTPApiClient{client: httpClient}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	url := "http://example.com/fantasy"
+	_, err := client.Get(ctx, url)
+	if err != nil {
+		t.Fatalf("error retrieving response: %s", err)
+	}
+
+	if httpClient.LastRequest == nil ||
+		httpClient.LastRequest.Context() != ctx {
+		t.Fatalf("context not passed to HTTP client\n\trequest: %+v",
+			httpClient.LastRequest)
+	}
+
+	if httpClient.LastURL != url {
+		t.Fatalf("unexpected URL requested\n\texpected: %s\n\tactual: %s",
+			url,
+			httpClient.LastURL)
+	}
+}
+
+func TestCountingHTTPClientSend(t *testing.T) {
+	httpClient := &mockHTTPClient{Response: &http.Response{}}
+	client := &countingHTTPApiClient{
+		client:    httpClient,
+		userAgent: "goff-test",
+	}
+
+	body := []byte("<fantasy_content/>")
+	url := "http://example.com/fantasy/league/223.l.431/transactions"
+	_, err := client.Send(context.Background(), http.MethodPost, url, body)
+	if err != nil {
+		t.Fatalf("error sending request: %s", err)
+	}
+
+	request := httpClient.LastRequest
+	assertStringEquals(t, http.MethodPost, request.Method)
+	assertStringEquals(t, url, httpClient.LastURL)
+	assertStringEquals(t, "application/xml", request.Header.Get("Content-Type"))
+	assertStringEquals(t, "goff-test", request.Header.Get("User-Agent"))
+
+	sent, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		t.Fatalf("error reading request body: %s", err)
+	}
+	assertStringEquals(t, string(body), string(sent))
+	assertIntEquals(t, 1, client.RequestCountByResource()["league"])
+}
+
+func TestCountingHTTPClientCanceledContextStopsRetries(t *testing.T) {
+	httpClient := &mockHTTPClient{
+		Response:   &http.Response{},
+		Error:      errors.New("consumer_key_unknown"),
+		ErrorCount: 5,
+	}
+	client := &countingHTTPApiClient{client: httpClient}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.Get(ctx, "http://example.com")
+	if err == nil {
+		t.Fatalf("no error returned from client when context was canceled")
+	}
+
+	if httpClient.RequestCount != 1 {
+		t.Fatalf("requests retried after context was canceled\n"+
+			"\texpected: 1\n\tactual: %d",
+			httpClient.RequestCount)
+	}
+}
+
+func TestCountingHTTPClientRequestCountByResource(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: &http.Response{}},
+	}
+
+	urls := []string{
+		YahooBaseURL + "/league/223.l.431;out=standings,settings",
+		YahooBaseURL + "/league/223.l.431/players;player_keys=1,2/stats",
+		YahooBaseURL + "/team/223.l.431.t.1/roster;week=2",
+		YahooBaseURL + "/users;use_login=1/games;game_keys=nfl/leagues",
+		YahooBaseURL + "/players;player_keys=223.p.1",
+	}
+	for _, url := range urls {
+		client.Get(context.Background(), url)
+	}
+
+	counts := client.RequestCountByResource()
+	expected := map[string]int{
+		"league":  2,
+		"team":    1,
+		"users":   1,
+		"players": 1,
+	}
+	if len(counts) != len(expected) {
+		t.Fatalf("Unexpected request counts\n\texpected: %+v\n\tactual: %+v",
+			expected,
+			counts)
+	}
+	for resource, count := range expected {
+		if counts[resource] != count {
+			t.Fatalf("Unexpected request count for %s\n\texpected: %+v\n\t"+
+				"actual: %+v",
+				resource,
+				expected,
+				counts)
+		}
+	}
+
+	counts["league"] = 100
+	assertIntEquals(t, 2, client.RequestCountByResource()["league"])
+	assertIntEquals(t, 5, client.RequestCount())
+}
+
+func TestResourceType(t *testing.T) {
+	tests := map[string]string{
+		YahooBaseURL + "/league/223.l.431":                  "league",
+		YahooBaseURL + "/leagues;league_keys=223.l.431":     "leagues",
+		YahooBaseURL + "/team/223.l.431.t.1;out=stats":      "team",
+		YahooBaseURL + "/users;use_login=1/games/leagues":   "users",
+		YahooBaseURL + "/games;game_codes=nfl;seasons=2022": "games",
+		YahooBaseURL + "/game/nfl":                          "game",
+		"http://127.0.0.1:8080/players;player_keys=223.p.1": "players",
+		"http://example.com":                                otherResource,
+		"%":                                                 otherResource,
+	}
+
+	for url, expected := range tests {
+		assertStringEquals(t, expected, resourceType(url))
+	}
+}
+
 //
 // Test cachedContentProvider
 //
@@ -366,7 +602,7 @@ func TestCachedGetNoContentInCache(t *testing.T) {
 	}
 
 	url := "http://example.com/fantasy"
-	actualContent, err := provider.Get(url)
+	actualContent, err := provider.Get(context.Background(), url)
 
 	if actualContent != expectedContent {
 		t.Fatalf("Actual content did not equal expected content\n"+
@@ -406,7 +642,7 @@ func TestCachedGetWithContentInCache(t *testing.T) {
 
 	url := "http://example.com/fantasy"
 	cache.data[url] = expectedContent
-	actualContent, err := provider.Get(url)
+	actualContent, err := provider.Get(context.Background(), url)
 
 	if actualContent != expectedContent {
 		t.Fatalf("Actual content did not equal expected content\n"+
@@ -440,7 +676,7 @@ func TestCachedGetNoContentInCacheErrorReturnedCacheNotSet(t *testing.T) {
 	}
 
 	url := "http://example.com/fantasy"
-	_, actualErr := provider.Get(url)
+	_, actualErr := provider.Get(context.Background(), url)
 
 	if actualErr != err {
 		t.Fatalf("Cached provider did not return expected error: \n\t"+
@@ -460,10 +696,621 @@ func TestCachedGetNoContentInCacheErrorReturnedCacheNotSet(t *testing.T) {
 	}
 }
 
+type contextKey string
+
+func TestCachedGetPassesContextToDelegate(t *testing.T) {
+	delegate := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    mockCache(),
+	}
+
+	key := contextKey("key")
+	ctx, cancel := context.WithCancel(
+		context.WithValue(context.Background(), key, "value"))
+	defer cancel()
+	provider.Get(ctx, "http://example.com/fantasy")
+
+	if delegate.lastGetContext == nil ||
+		delegate.lastGetContext.Value(key) != "value" {
+		t.Fatalf("Cached provider did not pass context to delegate\n"+
+			"\texpected: %+v\n\tactual: %+v",
+			ctx,
+			delegate.lastGetContext)
+	}
+}
+
+func TestCachedGetSharesConcurrentRequests(t *testing.T) {
+	delegate := newBlockingContentProvider(&FantasyContent{}, nil)
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    &mockConcurrentCache{},
+	}
+
+	url := "http://example.com/fantasy/league/223.l.431/scoreboard"
+	callers := 50
+	results := make(chan error, callers)
+	contents := make(chan *FantasyContent, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			content, err := provider.Get(context.Background(), url)
+			contents <- content
+			results <- err
+		}()
+	}
+
+	waitForWaiters(t, provider, url, callers)
+	close(delegate.release)
+
+	for i := 0; i < callers; i++ {
+		if err := <-results; err != nil {
+			t.Fatalf("unexpected error returned: %s", err)
+		}
+		if content := <-contents; content != delegate.content {
+			t.Fatalf("Unexpected content returned\n\texpected: %+v\n\t"+
+				"actual: %+v",
+				delegate.content,
+				content)
+		}
+	}
+	assertIntEquals(t, 1, provider.RequestCount())
+
+	provider.mutex.Lock()
+	inFlight := len(provider.inFlight)
+	provider.mutex.Unlock()
+	assertIntEquals(t, 0, inFlight)
+}
+
+func TestCachedGetSharesErrors(t *testing.T) {
+	expectedErr := errors.New("error")
+	delegate := newBlockingContentProvider(nil, expectedErr)
+	cache := &mockConcurrentCache{}
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    cache,
+	}
+
+	url := "http://example.com/fantasy"
+	callers := 5
+	results := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			_, err := provider.Get(context.Background(), url)
+			results <- err
+		}()
+	}
+
+	waitForWaiters(t, provider, url, callers)
+	close(delegate.release)
+
+	for i := 0; i < callers; i++ {
+		if err := <-results; err != expectedErr {
+			t.Fatalf("Unexpected error returned\n\texpected: %s\n\t"+
+				"actual: %s",
+				expectedErr,
+				err)
+		}
+	}
+	assertIntEquals(t, 1, provider.RequestCount())
+	assertIntEquals(t, 0, cache.setCount())
+
+	// Failed requests are not shared with later callers
+	if _, err := provider.Get(context.Background(), url); err != expectedErr {
+		t.Fatalf("Unexpected error returned: %s", err)
+	}
+	assertIntEquals(t, 2, provider.RequestCount())
+}
+
+func TestCachedGetCallerCanceled(t *testing.T) {
+	delegate := newBlockingContentProvider(&FantasyContent{}, nil)
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    &mockConcurrentCache{},
+	}
+
+	url := "http://example.com/fantasy"
+	ctx, cancel := context.WithCancel(context.Background())
+	canceledResult := make(chan error, 1)
+	go func() {
+		_, err := provider.Get(ctx, url)
+		canceledResult <- err
+	}()
+	result := make(chan error, 1)
+	go func() {
+		_, err := provider.Get(context.Background(), url)
+		result <- err
+	}()
+
+	waitForWaiters(t, provider, url, 2)
+	cancel()
+	if err := <-canceledResult; err != context.Canceled {
+		t.Fatalf("Unexpected error returned for canceled caller\n\t"+
+			"expected: %s\n\tactual: %s",
+			context.Canceled,
+			err)
+	}
+
+	close(delegate.release)
+	if err := <-result; err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+	if err := <-delegate.contextErr; err != nil {
+		t.Fatalf("Shared request canceled by a single caller: %s", err)
+	}
+	assertIntEquals(t, 1, provider.RequestCount())
+}
+
+func TestCachedGetAllCallersCanceled(t *testing.T) {
+	delegate := newBlockingContentProvider(&FantasyContent{}, nil)
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    &mockConcurrentCache{},
+	}
+
+	url := "http://example.com/fantasy"
+	ctx, cancel := context.WithCancel(context.Background())
+	callers := 3
+	results := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			_, err := provider.Get(ctx, url)
+			results <- err
+		}()
+	}
+
+	waitForWaiters(t, provider, url, callers)
+	cancel()
+	for i := 0; i < callers; i++ {
+		if err := <-results; err != context.Canceled {
+			t.Fatalf("Unexpected error returned\n\texpected: %s\n\t"+
+				"actual: %s",
+				context.Canceled,
+				err)
+		}
+	}
+
+	if err := <-delegate.contextErr; err != context.Canceled {
+		t.Fatalf("Shared request not canceled\n\texpected: %s\n\t"+
+			"actual: %v",
+			context.Canceled,
+			err)
+	}
+}
+
+func TestCachedGetAfterAllCallersCanceled(t *testing.T) {
+	delegate := &slowCancelContentProvider{
+		content:  &FantasyContent{},
+		releases: []chan struct{}{make(chan struct{}), make(chan struct{})},
+	}
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    &mockConcurrentCache{},
+	}
+
+	url := "http://example.com/fantasy"
+	ctx, cancel := context.WithCancel(context.Background())
+	canceledResult := make(chan error, 1)
+	go func() {
+		_, err := provider.Get(ctx, url)
+		canceledResult <- err
+	}()
+	waitForWaiters(t, provider, url, 1)
+	cancel()
+	if err := <-canceledResult; err != context.Canceled {
+		t.Fatalf("Unexpected error returned for canceled caller\n\t"+
+			"expected: %s\n\tactual: %s",
+			context.Canceled,
+			err)
+	}
+
+	// The canceled request is still running, but new callers do not join it
+	results := make(chan error, 2)
+	get := func() {
+		_, err := provider.Get(context.Background(), url)
+		results <- err
+	}
+	go get()
+	waitForWaiters(t, provider, url, 1)
+
+	// Finishing the canceled request does not remove the new one
+	close(delegate.releases[0])
+	go get()
+	waitForWaiters(t, provider, url, 2)
+
+	close(delegate.releases[1])
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Fatalf("unexpected error returned: %s", err)
+		}
+	}
+	assertIntEquals(t, 2, provider.RequestCount())
+}
+
+// waitForWaiters waits until the given number of callers are waiting on the
+// shared request for the URL.
+func waitForWaiters(t *testing.T, provider *cachedContentProvider, url string, count int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		provider.mutex.Lock()
+		request, ok := provider.inFlight[url]
+		waiters := 0
+		if ok {
+			waiters = request.waiters
+		}
+		provider.mutex.Unlock()
+		if waiters == count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Callers did not share request for %s", url)
+}
+
+func TestCachedGetLoginRelativeWithoutUser(t *testing.T) {
+	cache := mockCache()
+	delegate := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    cache,
+	}
+
+	url := "http://example.com/fantasy/users;use_login=1/games"
+	cache.data[url] = createLeagueList(League{LeagueKey: "123"})
+	content, err := provider.Get(context.Background(), url)
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	if content != delegate.content {
+		t.Fatalf("Cached provider did not return content from delegate\n"+
+			"\texpected: %+v\n\tactual: %+v",
+			delegate.content,
+			content)
+	}
+	assertStringEquals(t, "", cache.lastGetURL)
+	assertStringEquals(t, "", cache.lastSetURL)
+}
+
+func TestCachedGetScopesKeyToUser(t *testing.T) {
+	urls := []string{
+		"http://example.com/fantasy/users;use_login=1/games",
+		"http://example.com/fantasy/league/223.l.431",
+	}
+
+	for _, url := range urls {
+		cache := mockCache()
+		provider := &cachedContentProvider{
+			delegate: &mockedContentProvider{content: &FantasyContent{}},
+			cache:    cache,
+			user:     "user a",
+		}
+
+		if _, err := provider.Get(context.Background(), url); err != nil {
+			t.Fatalf("unexpected error returned: %s", err)
+		}
+
+		expectedKey := url + "#user=user+a"
+		assertStringEquals(t, expectedKey, cache.lastGetURL)
+		assertStringEquals(t, expectedKey, cache.lastSetURL)
+	}
+}
+
+func TestCachedClientUsersDoNotShareContent(t *testing.T) {
+	cache := NewLRUCache(
+		"clientID",
+		time.Hour,
+		lru.NewLRUCache(100, func(_ any) int64 {
+			return 1
+		}))
+	newUserClient := func(user string, leagueKey string) (*Client, *mockConcurrentHTTPClient) {
+		httpClient := &mockConcurrentHTTPClient{
+			content: `<fantasy_content><users><user><games><game><leagues>` +
+				`<league><league_key>` + leagueKey + `</league_key></league>` +
+				`</leagues></game></games></user></users></fantasy_content>`,
+		}
+		options := []Option{}
+		if user != "" {
+			options = append(options, WithCacheUser(user))
+		}
+		return NewCachedClient(cache, httpClient, options...), httpClient
+	}
+
+	clientA, httpClientA := newUserClient("a", "223.l.1")
+	clientB, _ := newUserClient("b", "223.l.2")
+	anonymous, anonymousHTTPClient := newUserClient("", "223.l.3")
+
+	getLeagues := func() {
+		for _, test := range []struct {
+			client    *Client
+			leagueKey string
+		}{
+			{client: clientA, leagueKey: "223.l.1"},
+			{client: clientB, leagueKey: "223.l.2"},
+			{client: anonymous, leagueKey: "223.l.3"},
+		} {
+			leagues, err := test.client.GetUserLeagues("2013")
+			if err != nil {
+				t.Fatalf("Client returned unexpected error: %s", err)
+			}
+			assertIntEquals(t, 1, len(leagues))
+			assertStringEquals(t, test.leagueKey, leagues[0].LeagueKey)
+		}
+	}
+
+	getLeagues()
+	requestsA := atomic.LoadInt64(&httpClientA.requests)
+	anonymousRequests := atomic.LoadInt64(&anonymousHTTPClient.requests)
+
+	// Only the client without a user requests its leagues again
+	getLeagues()
+	assertIntEquals(t,
+		int(requestsA),
+		int(atomic.LoadInt64(&httpClientA.requests)))
+	assertIntEquals(t,
+		int(anonymousRequests+1),
+		int(atomic.LoadInt64(&anonymousHTTPClient.requests)))
+}
+
+func TestCachedClientsWithoutUserDoNotShareTeams(t *testing.T) {
+	cache := NewLRUCache(
+		"clientID",
+		time.Hour,
+		lru.NewLRUCache(100, func(_ any) int64 {
+			return 1
+		}))
+	newClient := func(owned string) (*Client, *mockConcurrentHTTPClient) {
+		httpClient := &mockConcurrentHTTPClient{
+			content: `<fantasy_content><team><team_key>223.l.431.t.1</team_key>` +
+				`<team_id>1</team_id><name>Team Name</name>` +
+				`<is_owned_by_current_login>` + owned + `</is_owned_by_current_login>` +
+				`<managers><manager><is_current_login>` + owned + `</is_current_login>` +
+				`</manager></managers></team></fantasy_content>`,
+		}
+		return NewCachedClient(cache, httpClient), httpClient
+	}
+
+	// A user that does not own the team fills the cache first
+	other, otherHTTPClient := newClient("0")
+	owner, ownerHTTPClient := newClient("1")
+
+	for i := 0; i < 2; i++ {
+		team, err := other.GetTeam("223.l.431.t.1")
+		if err != nil {
+			t.Fatalf("Client returned unexpected error: %s", err)
+		}
+		assertBoolEquals(t, false, team.IsOwnedByCurrentLogin)
+		assertBoolEquals(t, false, team.Managers[0].IsCurrentLogin)
+
+		team, err = owner.GetTeam("223.l.431.t.1")
+		if err != nil {
+			t.Fatalf("Client returned unexpected error: %s", err)
+		}
+		assertBoolEquals(t, true, team.IsOwnedByCurrentLogin)
+		assertBoolEquals(t, true, team.Managers[0].IsCurrentLogin)
+	}
+
+	assertIntEquals(t, 2, int(atomic.LoadInt64(&otherHTTPClient.requests)))
+	assertIntEquals(t, 2, int(atomic.LoadInt64(&ownerHTTPClient.requests)))
+}
+
+func TestHasTeams(t *testing.T) {
+	teams := []Team{Team{TeamKey: "223.l.431.t.1"}}
+	tests := []struct {
+		name     string
+		content  *FantasyContent
+		expected bool
+	}{
+		{
+			name:     "no teams",
+			content:  &FantasyContent{},
+			expected: false,
+		},
+		{
+			name: "league players",
+			content: &FantasyContent{
+				League: League{
+					LeagueKey: "223.l.431",
+					Players:   []Player{Player{PlayerKey: "223.p.1"}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:     "team",
+			content:  &FantasyContent{Team: teams[0]},
+			expected: true,
+		},
+		{
+			name: "team managers",
+			content: &FantasyContent{
+				Team: Team{Managers: []Manager{Manager{Nickname: "Nickname"}}},
+			},
+			expected: true,
+		},
+		{
+			name:     "league teams",
+			content:  &FantasyContent{League: League{Teams: teams}},
+			expected: true,
+		},
+		{
+			name:     "standings",
+			content:  &FantasyContent{League: League{Standings: teams}},
+			expected: true,
+		},
+		{
+			name: "scoreboard",
+			content: &FantasyContent{
+				League: League{
+					Scoreboard: Scoreboard{
+						Matchups: []Matchup{Matchup{Teams: teams}},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "user leagues",
+			content: &FantasyContent{
+				Users: []User{
+					User{
+						Games: []Game{
+							Game{Leagues: []League{League{Teams: teams}}},
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "game leagues",
+			content: &FantasyContent{
+				Games: []Game{
+					Game{Leagues: []League{League{Standings: teams}}},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		if actual := hasTeams(test.content); actual != test.expected {
+			t.Fatalf("Unexpected result for %s\n\texpected: %t\n\t"+
+				"actual: %t",
+				test.name,
+				test.expected,
+				actual)
+		}
+	}
+}
+
+func TestCachedSendSkipsCache(t *testing.T) {
+	delegate := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	cache := mockCache()
+	url := "http://example.com/fantasy"
+	cache.data[url] = &FantasyContent{}
+	provider := &cachedContentProvider{
+		delegate: delegate,
+		cache:    cache,
+	}
+
+	content, err := provider.Send(
+		context.Background(),
+		http.MethodPut,
+		url,
+		[]byte("<fantasy_content/>"))
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	if content != delegate.content {
+		t.Fatalf("Cached provider did not return content from delegate\n"+
+			"\texpected: %+v\n\tactual: %+v",
+			delegate.content,
+			content)
+	}
+	assertStringEquals(t, http.MethodPut, delegate.lastSendMethod)
+	assertStringEquals(t, "", cache.lastGetURL)
+	assertStringEquals(t, "", cache.lastSetURL)
+}
+
 //
 // Test xmlContentProvider
 //
 
+func TestXMLContentProviderSend(t *testing.T) {
+	response := mockResponse(teamXMLContent)
+	httpClient := &mockHTTPClient{Response: response}
+	provider := &xmlContentProvider{
+		client: &countingHTTPApiClient{client: httpClient},
+	}
+
+	content, err := provider.Send(
+		context.Background(),
+		http.MethodPut,
+		"http://example.com",
+		[]byte("<fantasy_content/>"))
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	assertStringEquals(t, http.MethodPut, httpClient.LastRequest.Method)
+	assertStringEquals(t, expectedTeam.TeamKey, content.Team.TeamKey)
+}
+
+func TestXMLContentProviderSendNoContent(t *testing.T) {
+	response := mockResponse("")
+	response.StatusCode = http.StatusNoContent
+	provider := &xmlContentProvider{
+		client: &countingHTTPApiClient{
+			client: &mockHTTPClient{Response: response},
+		},
+	}
+
+	content, err := provider.Send(
+		context.Background(),
+		http.MethodPut,
+		"http://example.com",
+		[]byte("<fantasy_content/>"))
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+	if content == nil {
+		t.Fatal("no content returned")
+	}
+}
+
+func TestXMLContentProviderSendEmptyResponse(t *testing.T) {
+	provider := &xmlContentProvider{
+		client: &countingHTTPApiClient{
+			client: &mockHTTPClient{Response: mockResponse("")},
+		},
+	}
+
+	content, err := provider.Send(
+		context.Background(),
+		http.MethodDelete,
+		"http://example.com",
+		nil)
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+	if content == nil {
+		t.Fatal("no content returned")
+	}
+
+	_, err = provider.Get(context.Background(), "http://example.com")
+	if err == nil {
+		t.Fatal("no error returned for empty response to Get")
+	}
+}
+
+func TestXMLContentProviderSendAPIError(t *testing.T) {
+	response := mockResponse(errorXMLContent)
+	response.StatusCode = http.StatusBadRequest
+	provider := &xmlContentProvider{
+		client: &countingHTTPApiClient{
+			client: &mockHTTPClient{Response: response},
+		},
+	}
+
+	_, err := provider.Send(
+		context.Background(),
+		http.MethodPost,
+		"http://example.com",
+		[]byte("<fantasy_content/>"))
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			ErrBadRequest,
+			err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Error was not an APIError: %v", err)
+	}
+	assertStringEquals(t, "Invalid league key.", apiErr.Description)
+}
+
 func TestXMLContentProviderGetLeague(t *testing.T) {
 	response := mockResponse(leagueXMLContent)
 	client := &countingHTTPApiClient{
@@ -474,7 +1321,7 @@ func TestXMLContentProviderGetLeague(t *testing.T) {
 	}
 
 	provider := &xmlContentProvider{client: client}
-	content, err := provider.Get("http://example.com")
+	content, err := provider.Get(context.Background(), "http://example.com")
 
 	if err != nil {
 		t.Fatalf("unexpected error returned: %s", err)
@@ -494,7 +1341,7 @@ func TestXMLContentProviderGetTeam(t *testing.T) {
 	}
 
 	provider := &xmlContentProvider{client: client}
-	content, err := provider.Get("http://example.com")
+	content, err := provider.Get(context.Background(), "http://example.com")
 
 	if err != nil {
 		t.Fatalf("unexpected error returned: %s", err)
@@ -515,7 +1362,7 @@ func TestXMLContentProviderGetError(t *testing.T) {
 	}
 
 	provider := &xmlContentProvider{client: client}
-	_, err := provider.Get("http://example.com")
+	_, err := provider.Get(context.Background(), "http://example.com")
 
 	if err == nil {
 		t.Fatalf("error not returned when consumer fails")
@@ -531,7 +1378,7 @@ func TestXMLContentProviderReadError(t *testing.T) {
 	}
 
 	provider := &xmlContentProvider{client: client}
-	_, err := provider.Get("http://example.com")
+	_, err := provider.Get(context.Background(), "http://example.com")
 
 	if err == nil {
 		t.Fatalf("error not returned when read fails")
@@ -547,7 +1394,7 @@ func TestXMLContentProviderParseError(t *testing.T) {
 	}
 
 	provider := &xmlContentProvider{client: client}
-	_, err := provider.Get("http://example.com")
+	_, err := provider.Get(context.Background(), "http://example.com")
 
 	if err == nil {
 		t.Fatalf("error not returned when parse fails")
@@ -602,7 +1449,7 @@ func TestXMLContentProviderEmptyTagsForNumberFields(t *testing.T) {
 	}
 
 	provider := &xmlContentProvider{client: client}
-	content, err := provider.Get("http://example.com")
+	content, err := provider.Get(context.Background(), "http://example.com")
 
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
@@ -624,129 +1471,169 @@ func TestXMLContentProviderEmptyTagsForNumberFields(t *testing.T) {
 	}
 }
 
-func TestFixContent(t *testing.T) {
-	expectedRank := 5
-	expectedRankStr := "5"
-	expectedPoints := 543.21
-	expectedPointsStr := "543.21"
-	team := Team{
-		TeamKey: "223.l.431.t.1",
-		TeamID:  1,
-		Name:    "Team Name",
-		Managers: []Manager{
-			Manager{
-				ManagerID: 13,
-				Nickname:  "Nickname",
-				GUID:      "1234567890",
-			},
-		},
-		TeamPoints: Points{
-			CoverageType: "week",
-			Week:         16,
-			TotalStr:     expectedPointsStr,
-		},
-		TeamProjectedPoints: Points{
-			CoverageType: "week",
-			Week:         16,
-			TotalStr:     expectedPointsStr,
-		},
-		TeamStandings: TeamStandings{
-			RankStr: expectedRankStr,
-		},
-		TeamLogos: []TeamLogo{
-			TeamLogo{
-				Size: "medium",
-				URL:  "http://example.com/logo.png",
-			},
-		},
-		Roster: Roster{
-			Players: []Player{
-				Player{PlayerPoints: Points{TotalStr: expectedPointsStr}},
-			},
-		},
-		Players: []Player{
-			Player{PlayerPoints: Points{TotalStr: expectedPointsStr}},
-		},
-		Matchups: []Matchup{
-			Matchup{Teams: []Team{expectedTeam, expectedTeam}},
-		},
+func TestXMLContentProviderParsesNestedPoints(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(nestedPointsXMLContent)},
 	}
 
-	content := &FantasyContent{
-		Team: team,
-		League: League{
-			Teams:     []Team{team},
-			Standings: []Team{team},
-			Players: []Player{
-				Player{PlayerPoints: Points{TotalStr: expectedPointsStr}},
-			},
-			Scoreboard: Scoreboard{
-				Matchups: []Matchup{Matchup{Teams: []Team{team, team}}},
-			},
-		},
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
 	}
 
-	actual := fixContent(content)
-	if actual != content {
-		t.Fatalf("Returned pointer should be the same as input:\n\t"+
-			"expected: %+v\n\actual: %+v", content, actual)
+	expectedPoints := 543.21
+	expectedRank := 5
+	team := content.League.Teams[0]
+	assertFloatEquals(t, expectedPoints, team.TeamPoints.Total)
+	assertFloatEquals(t, expectedPoints, team.TeamProjectedPoints.Total)
+	assertIntEquals(t, expectedRank, team.TeamStandings.Rank)
+	assertFloatEquals(t, expectedPoints, team.Roster.Players[0].PlayerPoints.Total)
+	assertFloatEquals(t, expectedPoints, team.Players[0].PlayerPoints.Total)
+	assertFloatEquals(t, expectedPoints, team.Matchups[0].Teams[0].TeamPoints.Total)
+	assertIntEquals(t, expectedRank, content.League.Standings[0].TeamStandings.Rank)
+	assertFloatEquals(t, expectedPoints, content.League.Players[0].PlayerPoints.Total)
+
+	matchups := content.League.Scoreboard.Matchups
+	if len(matchups) != 2 {
+		t.Fatalf("Unexpected matchups parsed: %+v", matchups)
+	}
+	assertIntEquals(t, 1, len(matchups[0].Teams))
+	assertFloatEquals(t, expectedPoints, matchups[0].Teams[0].TeamPoints.Total)
+	assertIntEquals(t, 0, len(matchups[1].Teams))
+}
+
+func TestXMLContentProviderInvalidPoints(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(`
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content>
+  <team>
+    <team_points>
+      <total>not a number</total>
+    </team_points>
+  </team>
+</fantasy_content>`)},
 	}
 
-	checkTeam(t, &actual.Team, expectedPoints, expectedRank)
-	checkTeam(t, &actual.League.Teams[0], expectedPoints, expectedRank)
-	checkTeam(t, &actual.League.Standings[0], expectedPoints, expectedRank)
-	checkTeam(t, &actual.League.Standings[0], expectedPoints, expectedRank)
-	if actual.League.Players[0].PlayerPoints.Total != expectedPoints {
-		t.Fatalf("Fantasy content not fixed for %s\n\tactual: %f\n\t"+
-			"expected: %f",
-			"League.Players.PlayerPoints.Total",
-			actual.League.Players[0].PlayerPoints.Total,
-			expectedPoints)
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err == nil {
+		t.Fatalf("no error returned for invalid points, content: %+v", content)
 	}
 }
 
-func checkTeam(t *testing.T, team *Team, expectedPoints float64, expectedRank int) {
-	if team.TeamPoints.Total != expectedPoints {
-		t.Fatalf("Fantasy content not fixed for %s\n\tactual: %f\n\t"+
-			"expected: %f",
-			"TeamPoints.Total",
-			team.TeamPoints.Total,
-			expectedPoints)
-	}
-
-	if team.TeamProjectedPoints.Total != expectedPoints {
-		t.Fatalf("Fantasy content not fixed for %s\n\tactual: %f\n\t"+
-			"expected: %f",
-			"TeamProjectedPoints.Total",
-			team.TeamProjectedPoints.Total,
-			expectedPoints)
-	}
-
-	if team.Roster.Players[0].PlayerPoints.Total != expectedPoints {
-		t.Fatalf("Fantasy content not fixed for %s\n\tactual: %f\n\t"+
-			"expected: %f",
-			"Roster.Players.PlayerPoints.Total",
-			team.Roster.Players[0].PlayerPoints.Total,
-			expectedPoints)
-	}
-
-	if team.Players[0].PlayerPoints.Total != expectedPoints {
-		t.Fatalf("Fantasy content not fixed for %s\n\tactual: %f\n\t"+
-			"expected: %f",
-			"Players.PlayerPoints.Total",
-			team.Players[0].PlayerPoints.Total,
-			expectedPoints)
+func TestXMLContentProviderInvalidRank(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(`
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content>
+  <league>
+    <standings>
+      <teams>
+        <team>
+          <team_standings>
+            <rank>first</rank>
+          </team_standings>
+        </team>
+      </teams>
+    </standings>
+  </league>
+</fantasy_content>`)},
 	}
 
-	if team.TeamStandings.Rank != expectedRank {
-		t.Fatalf("Fantasy content not fixed for %s\n\tactual: %d\n\t"+
-			"expected: %d",
-			"TeamStandings.Rank",
-			team.TeamStandings.Rank,
-			expectedRank)
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err == nil {
+		t.Fatalf("no error returned for invalid rank, content: %+v", content)
 	}
 }
 
+var nestedPointsXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content>
+  <league>
+    <league_key>223.l.431</league_key>
+    <teams>
+      <team>
+        <team_key>223.l.431.t.1</team_key>
+        <team_points>
+          <coverage_type>week</coverage_type>
+          <week>16</week>
+          <total>543.21</total>
+        </team_points>
+        <team_projected_points>
+          <coverage_type>week</coverage_type>
+          <week>16</week>
+          <total> 543.21 </total>
+        </team_projected_points>
+        <team_standings>
+          <rank>5</rank>
+        </team_standings>
+        <roster>
+          <players>
+            <player>
+              <player_points>
+                <total>543.21</total>
+              </player_points>
+            </player>
+          </players>
+        </roster>
+        <players>
+          <player>
+            <player_points>
+              <total>543.21</total>
+            </player_points>
+          </player>
+        </players>
+        <matchups>
+          <matchup>
+            <teams>
+              <team>
+                <team_points>
+                  <total>543.21</total>
+                </team_points>
+              </team>
+            </teams>
+          </matchup>
+        </matchups>
+      </team>
+    </teams>
+    <standings>
+      <teams>
+        <team>
+          <team_standings>
+            <rank>5</rank>
+          </team_standings>
+        </team>
+      </teams>
+    </standings>
+    <players>
+      <player>
+        <player_points>
+          <total>543.21</total>
+        </player_points>
+      </player>
+    </players>
+    <scoreboard>
+      <matchups>
+        <matchup>
+          <teams>
+            <team>
+              <team_points>
+                <total>543.21</total>
+              </team_points>
+            </team>
+          </teams>
+        </matchup>
+        <matchup>
+          <status>preevent</status>
+        </matchup>
+      </matchups>
+    </scoreboard>
+  </league>
+</fantasy_content>`
+
 type mockReaderCloser struct {
 	Reader    io.Reader
 	ReadError error
@@ -822,6 +1709,97 @@ func TestGetFantasyContentError(t *testing.T) {
 	}
 }
 
+func TestGetFantasyContentContext(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	url := "http://example.com"
+	client.GetFantasyContentContext(ctx, url)
+
+	if provider.lastGetContext != ctx {
+		t.Fatalf("Fantasy client did not pass context to provider\n"+
+			"\texpected: %+v\n\tactual: %+v",
+			ctx,
+			provider.lastGetContext)
+	}
+
+	if provider.lastGetURL != url {
+		t.Fatalf("Fantasy client requested unexpected URL\n"+
+			"\texpected: %s\n\tactual: %s",
+			url,
+			provider.lastGetURL)
+	}
+}
+
+func TestConvenienceFunctionsUseContext(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{Team: expectedTeam},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	type contextKey struct{}
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+	requests := map[string]func(){
+		"GetUserLeaguesContext": func() {
+			client.GetUserLeaguesContext(ctx, "2013")
+		},
+		"GetPlayersStatsContext": func() {
+			client.GetPlayersStatsContext(ctx, "123", 1, []Player{})
+		},
+		"GetTeamRosterContext": func() {
+			client.GetTeamRosterContext(ctx, "123", 1)
+		},
+		"GetPlayersStatsForDateContext": func() {
+			client.GetPlayersStatsForDateContext(ctx, "123", time.Now(), []Player{})
+		},
+		"GetTeamRosterForDateContext": func() {
+			client.GetTeamRosterForDateContext(ctx, "123", time.Now())
+		},
+		"GetUserLeaguesForGameContext": func() {
+			client.GetUserLeaguesForGameContext(ctx, MlbGameKey, "")
+		},
+		"SetLineupContext": func() {
+			client.SetLineupContext(ctx, "123", 1, map[string]string{"1": "QB"})
+		},
+		"AddPlayerContext": func() {
+			client.AddPlayerContext(ctx, "123", "123.t.1", "1")
+		},
+		"GetLeagueStandingsContext": func() {
+			client.GetLeagueStandingsContext(ctx, "123")
+		},
+		"GetAllTeamStatsContext": func() {
+			client.GetAllTeamStatsContext(ctx, "123", 1)
+		},
+		"GetTeamContext": func() {
+			client.GetTeamContext(ctx, "123")
+		},
+		"GetLeagueMetadataContext": func() {
+			client.GetLeagueMetadataContext(ctx, "123")
+		},
+		"GetAllTeamsContext": func() {
+			client.GetAllTeamsContext(ctx, "123")
+		},
+		"GetMatchupsForWeekRangeContext": func() {
+			client.GetMatchupsForWeekRangeContext(ctx, "123", 1, 2)
+		},
+	}
+
+	for name, request := range requests {
+		provider.lastGetContext = nil
+		request()
+		if provider.lastGetContext != ctx {
+			t.Fatalf("%s did not pass context to provider\n"+
+				"\texpected: %+v\n\tactual: %+v",
+				name,
+				ctx,
+				provider.lastGetContext)
+		}
+	}
+}
+
 func TestGetFantasyContentRequestcount(t *testing.T) {
 	client := mockClient(&FantasyContent{}, nil)
 	client.GetFantasyContent("http://example.com/RequestOne")
@@ -844,6 +1822,65 @@ func TestGetFantasyContentRequestcount(t *testing.T) {
 	}
 }
 
+func TestClientConcurrentRequests(t *testing.T) {
+	httpClient := &mockConcurrentHTTPClient{content: leagueXMLContent}
+	client := NewClient(httpClient)
+
+	requests := 50
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			league, err := client.GetLeagueMetadata(fmt.Sprintf("223.l.%d", i))
+			if err != nil {
+				t.Errorf("Client returned unexpected error: %s", err)
+				return
+			}
+			if league.LeagueKey != expectedLeague.LeagueKey {
+				t.Errorf("Unexpected league returned\n\texpected: %s\n\t"+
+					"actual: %s",
+					expectedLeague.LeagueKey,
+					league.LeagueKey)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assertIntEquals(t, requests, client.RequestCount())
+	assertIntEquals(t, requests, client.RequestCountByResource()["league"])
+	assertIntEquals(t, requests, int(atomic.LoadInt64(&httpClient.requests)))
+}
+
+func TestCachedClientConcurrentRequests(t *testing.T) {
+	httpClient := &mockConcurrentHTTPClient{content: leagueXMLContent}
+	cache := NewLRUCache(
+		"clientID",
+		time.Hour,
+		lru.NewLRUCache(100, func(_ any) int64 {
+			return 1
+		}))
+	client := NewCachedClient(cache, httpClient)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := client.GetLeagueMetadata(fmt.Sprintf("223.l.%d", i%5))
+			if err != nil {
+				t.Errorf("Client returned unexpected error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assertIntEquals(
+		t,
+		int(atomic.LoadInt64(&httpClient.requests)),
+		client.RequestCount())
+}
+
 //
 // Test GetUserLeagues
 //
@@ -1138,6 +2175,165 @@ func TestGetPlayerStatsParams(t *testing.T) {
 	assertURLContainsParam(t, provider.lastGetURL, "week", fmt.Sprintf("%d", week))
 }
 
+func TestGetPlayersStatsForDate(t *testing.T) {
+	players := []Player{
+		Player{PlayerKey: "422.p.9001", PlayerID: 9001},
+	}
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			League: League{
+				Players: players,
+			},
+		},
+		err: nil,
+	}
+	client := &Client{
+		Provider: provider,
+	}
+
+	date := time.Date(2023, time.May, 4, 0, 0, 0, 0, time.UTC)
+	actual, err := client.GetPlayersStatsForDate("422.l.1", date, players)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertPlayersEqual(t, &players[0], &actual[0])
+	assertURLContainsParam(t, provider.lastGetURL, "player_keys", players[0].PlayerKey)
+	assertURLContainsParam(t, provider.lastGetURL, "type", "date")
+	assertURLContainsParam(t, provider.lastGetURL, "date", "2023-05-04")
+}
+
+func TestGetPlayersStatsForDateError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetPlayersStatsForDate("422.l.1", time.Now(), []Player{})
+	if err == nil {
+		t.Fatalf("Client did not return error")
+	}
+}
+
+func TestGetPlayersStatsBatches(t *testing.T) {
+	players := make([]Player, 60)
+	for i := range players {
+		players[i] = Player{PlayerKey: fmt.Sprintf("223.p.%d", i)}
+	}
+	provider := &mockedPlayerKeysContentProvider{}
+	client := &Client{Provider: provider}
+
+	actual, err := client.GetPlayersStats("223.l.431", 1, players)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	if len(actual) != len(players) {
+		t.Fatalf("Unexpected number of players\n\texpected: %d\n\tactual: %d",
+			len(players),
+			len(actual))
+	}
+	for i := range players {
+		assertStringEquals(t, players[i].PlayerKey, actual[i].PlayerKey)
+	}
+
+	assertIntEquals(t, 3, provider.RequestCount())
+	for _, keys := range provider.requestedKeys() {
+		if len(keys) > maxPlayersPerPage {
+			t.Fatalf("Too many players requested at once: %d", len(keys))
+		}
+	}
+	if provider.maxActive > maxConcurrentBatches {
+		t.Fatalf("Too many concurrent requests: %d", provider.maxActive)
+	}
+}
+
+func TestGetPlayersStatsBatchError(t *testing.T) {
+	players := make([]Player, 60)
+	for i := range players {
+		players[i] = Player{PlayerKey: fmt.Sprintf("223.p.%d", i)}
+	}
+	expected := errors.New("error")
+	provider := &mockedPlayerKeysContentProvider{
+		failKey: players[30].PlayerKey,
+		err:     expected,
+	}
+	client := &Client{Provider: provider}
+
+	actual, err := client.GetPlayersStats("223.l.431", 1, players)
+	if !errors.Is(err, expected) {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			expected,
+			err)
+	}
+
+	// Players from the first and last batches are still returned
+	if len(actual) != 35 {
+		t.Fatalf("Unexpected number of players\n\texpected: %d\n\tactual: %d",
+			35,
+			len(actual))
+	}
+	assertStringEquals(t, players[24].PlayerKey, actual[24].PlayerKey)
+	assertStringEquals(t, players[50].PlayerKey, actual[25].PlayerKey)
+}
+
+// mockedPlayerKeysContentProvider is safe for concurrent use and returns the
+// players requested by the player_keys parameter.
+type mockedPlayerKeysContentProvider struct {
+	failKey string
+	err     error
+
+	mutex     sync.Mutex
+	keys      [][]string
+	active    int
+	maxActive int
+}
+
+func (m *mockedPlayerKeysContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	param := url[strings.Index(url, "player_keys=")+len("player_keys="):]
+	keys := strings.Split(param[:strings.Index(param, "/")], ",")
+
+	m.mutex.Lock()
+	m.keys = append(m.keys, keys)
+	m.active++
+	if m.active > m.maxActive {
+		m.maxActive = m.active
+	}
+	m.mutex.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	m.mutex.Lock()
+	m.active--
+	m.mutex.Unlock()
+
+	players := make([]Player, len(keys))
+	for i, key := range keys {
+		if key == m.failKey {
+			return nil, m.err
+		}
+		players[i] = Player{PlayerKey: key}
+	}
+	return &FantasyContent{League: League{Players: players}}, nil
+}
+
+func (m *mockedPlayerKeysContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	return m.Get(ctx, url)
+}
+
+func (m *mockedPlayerKeysContentProvider) RequestCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.keys)
+}
+
+func (m *mockedPlayerKeysContentProvider) RequestCountByResource() map[string]int {
+	return nil
+}
+
+func (m *mockedPlayerKeysContentProvider) requestedKeys() [][]string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.keys
+}
+
 //
 // Test GetTeamRoster
 //
@@ -1198,6 +2394,87 @@ func TestGetTeamRosterError(t *testing.T) {
 	}
 }
 
+func TestGetTeamRosterForDate(t *testing.T) {
+	players := []Player{
+		Player{
+			PlayerKey: "422.p.9001",
+			PlayerID:  9001,
+			SelectedPosition: SelectedPosition{
+				CoverageType: DateCoverage,
+				Date:         "2023-05-04",
+				Position:     "SS",
+			},
+		},
+	}
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			Team: Team{
+				Roster: Roster{
+					CoverageType: DateCoverage,
+					Date:         "2023-05-04",
+					Players:      players,
+				},
+			},
+		},
+		err: nil,
+	}
+	client := &Client{
+		Provider: provider,
+	}
+
+	date := time.Date(2023, time.May, 4, 0, 0, 0, 0, time.UTC)
+	actual, err := client.GetTeamRosterForDate("422.l.1.t.1", date)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertPlayersEqual(t, &players[0], &actual[0])
+	assertStringEquals(t, "2023-05-04", actual[0].SelectedPosition.Date)
+	assertURLContainsParam(t, provider.lastGetURL, "roster;date", "2023-05-04")
+}
+
+func TestGetTeamRosterForDateError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetTeamRosterForDate("422.l.1.t.1", time.Now())
+	if err == nil {
+		t.Fatalf("Client did not return error")
+	}
+}
+
+func TestXMLContentProviderGetDailyRoster(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(dailyRosterXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	roster := content.Team.Roster
+	assertStringEquals(t, DateCoverage, roster.CoverageType)
+	assertStringEquals(t, "2023-05-04", roster.Date)
+	if len(roster.Players) != 1 {
+		t.Fatalf("Unexpected players parsed: %+v", roster.Players)
+	}
+
+	player := roster.Players[0]
+	assertStringEquals(t, DateCoverage, player.SelectedPosition.CoverageType)
+	assertStringEquals(t, "2023-05-04", player.SelectedPosition.Date)
+	assertStringEquals(t, "SS", player.SelectedPosition.Position)
+	assertStringEquals(t, DateCoverage, player.PlayerPoints.CoverageType)
+	assertStringEquals(t, "2023-05-04", player.PlayerPoints.Date)
+	assertFloatEquals(t, 12.5, player.PlayerPoints.Total)
+
+	date, err := time.Parse(DateFormat, roster.Date)
+	if err != nil {
+		t.Fatalf("unable to parse roster date: %s", err)
+	}
+	assertIntEquals(t, 4, date.Day())
+}
+
 //
 // Test GetAllTeamStats
 //
@@ -1520,24 +2797,146 @@ func mockClient(f *FantasyContent, e error) *Client {
 }
 
 // mockedContentProvider creates a goff.ContentProvider that returns the
-// given content and error whenever Provider.Get is called.
+// given content and error whenever Provider.Get or Provider.Send is called.
 type mockedContentProvider struct {
-	lastGetURL string
-	content    *FantasyContent
-	err        error
-	count      int
+	lastGetURL     string
+	lastGetContext context.Context
+	lastSendMethod string
+	lastSendBody   []byte
+	content        *FantasyContent
+	err            error
+	count          int
 }
 
-func (m *mockedContentProvider) Get(url string) (*FantasyContent, error) {
+func (m *mockedContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
 	m.lastGetURL = url
+	m.lastGetContext = ctx
 	m.count++
 	return m.content, m.err
 }
 
+func (m *mockedContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	m.lastSendMethod = method
+	m.lastSendBody = body
+	return m.Get(ctx, url)
+}
+
 func (m *mockedContentProvider) RequestCount() int {
 	return m.count
 }
 
+func (m *mockedContentProvider) RequestCountByResource() map[string]int {
+	return map[string]int{resourceType(m.lastGetURL): m.count}
+}
+
+// blockingContentProvider is safe for concurrent use and blocks each request
+// until release is closed or the request's context is done. The error of the
+// context of each request is sent to contextErr once it returns.
+type blockingContentProvider struct {
+	content    *FantasyContent
+	err        error
+	release    chan struct{}
+	contextErr chan error
+	count      int64
+}
+
+func newBlockingContentProvider(content *FantasyContent, err error) *blockingContentProvider {
+	return &blockingContentProvider{
+		content:    content,
+		err:        err,
+		release:    make(chan struct{}),
+		contextErr: make(chan error, 10),
+	}
+}
+
+func (m *blockingContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	atomic.AddInt64(&m.count, 1)
+	select {
+	case <-m.release:
+		m.contextErr <- ctx.Err()
+		return m.content, m.err
+	case <-ctx.Done():
+		m.contextErr <- ctx.Err()
+		return nil, ctx.Err()
+	}
+}
+
+func (m *blockingContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	return m.Get(ctx, url)
+}
+
+func (m *blockingContentProvider) RequestCount() int {
+	return int(atomic.LoadInt64(&m.count))
+}
+
+func (m *blockingContentProvider) RequestCountByResource() map[string]int {
+	return map[string]int{"league": m.RequestCount()}
+}
+
+// slowCancelContentProvider is safe for concurrent use and blocks each
+// request until the release for that request is closed, even if its context
+// is done.
+type slowCancelContentProvider struct {
+	content  *FantasyContent
+	releases []chan struct{}
+	count    int64
+}
+
+func (m *slowCancelContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	request := atomic.AddInt64(&m.count, 1)
+	<-m.releases[request-1]
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.content, nil
+}
+
+func (m *slowCancelContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	return m.Get(ctx, url)
+}
+
+func (m *slowCancelContentProvider) RequestCount() int {
+	return int(atomic.LoadInt64(&m.count))
+}
+
+func (m *slowCancelContentProvider) RequestCountByResource() map[string]int {
+	return nil
+}
+
+// mockConcurrentCache is safe for concurrent use and never has content.
+type mockConcurrentCache struct {
+	mutex sync.Mutex
+	sets  int
+}
+
+func (c *mockConcurrentCache) Set(url string, time time.Time, content *FantasyContent) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sets++
+}
+
+func (c *mockConcurrentCache) Get(url string, time time.Time) (*FantasyContent, bool) {
+	return nil, false
+}
+
+func (c *mockConcurrentCache) setCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.sets
+}
+
+// mockConcurrentHTTPClient is safe for concurrent use and responds to each
+// request with the given content.
+type mockConcurrentHTTPClient struct {
+	content  string
+	requests int64
+}
+
+func (m *mockConcurrentHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&m.requests, 1)
+	return mockResponse(m.content), nil
+}
+
 type mockedCache struct {
 	data           map[string](*FantasyContent)
 	lastSetURL     string
@@ -1572,16 +2971,18 @@ func (c *mockedCache) Get(
 }
 
 type mockHTTPClient struct {
-	Response   *http.Response
-	Error      error
-	ErrorCount int
-	LastURL    string
+	Response    *http.Response
+	Error       error
+	ErrorCount  int
+	LastURL     string
+	LastRequest *http.Request
 
 	RequestCount int
 }
 
-func (m *mockHTTPClient) Get(url string) (*http.Response, error) {
-	m.LastURL = url
+func (m *mockHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	m.LastURL = request.URL.String()
+	m.LastRequest = request
 	m.RequestCount++
 	err := m.Error
 	if m.RequestCount > m.ErrorCount {
@@ -1691,3 +3092,39 @@ var leagueXMLContent = `
         <is_finished>` + fmt.Sprintf("%t", expectedLeague.IsFinished) + `</is_finished>
       </league>
     </fantasy_content>`
+
+var dailyRosterXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/team/422.l.1.t.1/roster;date=2023-05-04" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="31.862020492554ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <team>
+    <team_key>422.l.1.t.1</team_key>
+    <team_id>1</team_id>
+    <name>Team Name</name>
+    <roster>
+      <coverage_type>date</coverage_type>
+      <date>2023-05-04</date>
+      <players count="1">
+        <player>
+          <player_key>422.p.9001</player_key>
+          <player_id>9001</player_id>
+          <name>
+            <full>Firstname Lastname</full>
+            <first>Firstname</first>
+            <last>Lastname</last>
+          </name>
+          <display_position>SS</display_position>
+          <selected_position>
+            <coverage_type>date</coverage_type>
+            <date>2023-05-04</date>
+            <position>SS</position>
+          </selected_position>
+          <player_points>
+            <coverage_type>date</coverage_type>
+            <date>2023-05-04</date>
+            <total>12.5</total>
+          </player_points>
+        </player>
+      </players>
+    </roster>
+  </team>
+</fantasy_content>`
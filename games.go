@@ -0,0 +1,103 @@
+package goff
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+//
+// Games
+//
+
+// GetGames returns the games matching the given game codes, e.g. NflGameKey,
+// and seasons. Either list can be empty to not filter by that value.
+func (c *Client) GetGames(gameCodes []string, seasons []string) ([]Game, error) {
+	return c.GetGamesContext(context.Background(), gameCodes, seasons)
+}
+
+// GetGamesContext returns the games matching the given game codes and
+// seasons using the given context for the API request.
+func (c *Client) GetGamesContext(ctx context.Context, gameCodes []string, seasons []string) ([]Game, error) {
+	url := c.baseURL() + "/games"
+	if len(gameCodes) > 0 {
+		url += ";game_codes=" + strings.Join(gameCodes, ",")
+	}
+	if len(seasons) > 0 {
+		url += ";seasons=" + strings.Join(seasons, ",")
+	}
+
+	content, err := c.GetFantasyContentContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return content.Games, nil
+}
+
+// GetGameKey returns the key Yahoo uses to identify the game with the given
+// code, e.g. NflGameKey, for the given season. Keys are looked up with
+// GetGames and cached by the client. YearKeys is used as a fallback for
+// football seasons when the key can not be looked up.
+func (c *Client) GetGameKey(gameCode string, season string) (string, error) {
+	return c.GetGameKeyContext(context.Background(), gameCode, season)
+}
+
+// GetGameKeyContext returns the key Yahoo uses to identify the game with the
+// given code for the given season using the given context for any API
+// request.
+func (c *Client) GetGameKeyContext(ctx context.Context, gameCode string, season string) (string, error) {
+	if key, ok := c.cachedGameKey(gameCode, season); ok {
+		return key, nil
+	}
+
+	games, err := c.GetGamesContext(ctx, []string{gameCode}, []string{season})
+	if err == nil {
+		for _, game := range games {
+			if game.GameKey != "" &&
+				game.Season == season &&
+				(game.Code == "" || game.Code == gameCode) {
+				c.cacheGameKey(gameCode, season, game.GameKey)
+				return game.GameKey, nil
+			}
+		}
+	}
+
+	if key, ok := fallbackGameKey(gameCode, season); ok {
+		return key, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("data not available for game=%s, year=%s",
+		gameCode,
+		season)
+}
+
+// fallbackGameKey returns the known game key for the given game code and
+// season, if there is one.
+func fallbackGameKey(gameCode string, season string) (string, bool) {
+	if gameCode != NflGameKey {
+		return "", false
+	}
+	key, ok := YearKeys[season]
+	return key, ok
+}
+
+// cachedGameKey returns a game key previously found by GetGameKey.
+func (c *Client) cachedGameKey(gameCode string, season string) (string, bool) {
+	c.gameKeysMutex.Lock()
+	defer c.gameKeysMutex.Unlock()
+	key, ok := c.gameKeys[gameCode+":"+season]
+	return key, ok
+}
+
+// cacheGameKey stores a game key found by GetGameKey.
+func (c *Client) cacheGameKey(gameCode string, season string, key string) {
+	c.gameKeysMutex.Lock()
+	defer c.gameKeysMutex.Unlock()
+	if c.gameKeys == nil {
+		c.gameKeys = make(map[string]string)
+	}
+	c.gameKeys[gameCode+":"+season] = key
+}
@@ -0,0 +1,294 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//
+// Test GetGames
+//
+
+func TestGetGames(t *testing.T) {
+	games := []Game{
+		Game{GameKey: "423", Code: "nfl", Season: "2023"},
+		Game{GameKey: "422", Code: "mlb", Season: "2023"},
+	}
+	provider := &mockedContentProvider{
+		content: &FantasyContent{Games: games},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	actual, err := client.GetGames([]string{"nfl", "mlb"}, []string{"2023"})
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	if len(actual) != len(games) {
+		t.Fatalf("Unexpected games returned\n\texpected: %+v\n\tactual: %+v",
+			games,
+			actual)
+	}
+	assertStringEquals(t, games[0].GameKey, actual[0].GameKey)
+	assertStringEquals(t, games[1].GameKey, actual[1].GameKey)
+	assertURLContainsParam(t, provider.lastGetURL, "game_codes", "nfl,mlb")
+	assertURLContainsParam(t, provider.lastGetURL, "seasons", "2023")
+}
+
+func TestGetGamesNoFilters(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	client.GetGames(nil, nil)
+	assertStringEquals(t, YahooBaseURL+"/games", provider.lastGetURL)
+}
+
+func TestGetGamesError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+	_, err := client.GetGames([]string{"nfl"}, nil)
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+func TestXMLContentProviderGetGames(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(gamesXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	if len(content.Games) != 1 {
+		t.Fatalf("Unexpected games parsed: %+v", content.Games)
+	}
+	game := content.Games[0]
+	assertStringEquals(t, "423", game.GameKey)
+	assertUintEquals(t, 423, game.GameID)
+	assertStringEquals(t, "Football", game.Name)
+	assertStringEquals(t, "nfl", game.Code)
+	assertStringEquals(t, "full", game.Type)
+	assertStringEquals(t, "2023", game.Season)
+	assertBoolEquals(t, true, game.IsRegistrationOver)
+	assertBoolEquals(t, false, game.IsGameOver)
+	assertBoolEquals(t, false, game.IsOffseason)
+}
+
+//
+// Test GetGameKey
+//
+
+func TestGetGameKeyDiscovered(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			Games: []Game{Game{GameKey: "423", Code: "nfl", Season: "2023"}},
+		},
+		err: nil,
+	}
+	client := &Client{Provider: provider}
+
+	for i := 0; i < 2; i++ {
+		key, err := client.GetGameKey(NflGameKey, "2023")
+		if err != nil {
+			t.Fatalf("Client returned unexpected error: %s", err)
+		}
+		assertStringEquals(t, "423", key)
+	}
+
+	assertIntEquals(t, 1, provider.count)
+	assertURLContainsParam(t, provider.lastGetURL, "game_codes", NflGameKey)
+	assertURLContainsParam(t, provider.lastGetURL, "seasons", "2023")
+}
+
+func TestGetGameKeyIgnoresOtherSeasons(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			Games: []Game{Game{GameKey: "414", Code: "nfl", Season: "2022"}},
+		},
+		err: nil,
+	}
+	client := &Client{Provider: provider}
+
+	_, err := client.GetGameKey(NflGameKey, "2030")
+	if err == nil {
+		t.Fatal("Client did not return error for unknown season")
+	}
+}
+
+func TestGetGameKeyFallback(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	key, err := client.GetGameKey(NflGameKey, "2013")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+	assertStringEquals(t, YearKeys["2013"], key)
+}
+
+func TestGetGameKeyError(t *testing.T) {
+	expected := errors.New("error")
+	client := mockClient(nil, expected)
+
+	_, err := client.GetGameKey("mlb", "2013")
+	if err != expected {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			expected,
+			err)
+	}
+}
+
+func TestGetGameKeyConcurrent(t *testing.T) {
+	client := NewClient(&mockConcurrentHTTPClient{content: gamesXMLContent})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key, err := client.GetGameKey(NflGameKey, "2023")
+			if err != nil || key != "423" {
+				t.Errorf("Unexpected game key returned\n\tkey: %s\n\terr: %v",
+					key,
+					err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGetUserLeaguesDiscoversGameKey(t *testing.T) {
+	provider := &mockedRoutingContentProvider{
+		content: map[string]*FantasyContent{
+			"/games;game_codes=": &FantasyContent{
+				Games: []Game{Game{GameKey: "423", Code: "nfl", Season: "2023"}},
+			},
+			"/users;": createLeagueList(expectedLeague),
+		},
+	}
+	client := &Client{Provider: provider}
+
+	leagues, err := client.GetUserLeagues("2023")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertLeaguesEqual(t, []League{expectedLeague}, leagues)
+	assertURLContainsParam(t, provider.lastGetURL, "game_keys", "423")
+}
+
+func TestGetUserLeaguesCurrentGame(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: createLeagueList(expectedLeague),
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	if _, err := client.GetUserLeagues(NflGameKey); err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertIntEquals(t, 1, provider.count)
+	assertURLContainsParam(t, provider.lastGetURL, "game_keys", NflGameKey)
+}
+
+func TestGetUserLeaguesForGame(t *testing.T) {
+	provider := &mockedRoutingContentProvider{
+		content: map[string]*FantasyContent{
+			"/games;game_codes=": &FantasyContent{
+				Games: []Game{Game{GameKey: "422", Code: "mlb", Season: "2023"}},
+			},
+			"/users;": createLeagueList(expectedLeague),
+		},
+	}
+	client := &Client{Provider: provider}
+
+	leagues, err := client.GetUserLeaguesForGame(MlbGameKey, "2023")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertLeaguesEqual(t, []League{expectedLeague}, leagues)
+	assertURLContainsParam(t, provider.lastGetURL, "game_keys", "422")
+}
+
+func TestGetUserLeaguesForGameCurrentSeason(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: createLeagueList(expectedLeague),
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	if _, err := client.GetUserLeaguesForGame(NbaGameKey, ""); err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertIntEquals(t, 1, provider.count)
+	assertURLContainsParam(t, provider.lastGetURL, "game_keys", NbaGameKey)
+}
+
+func TestGetUserLeaguesForGameNoFallback(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	// YearKeys only contains football seasons
+	_, err := client.GetUserLeaguesForGame(NhlGameKey, "2013")
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+// mockedRoutingContentProvider returns the content mapped to the first key
+// contained in the requested URL.
+type mockedRoutingContentProvider struct {
+	content    map[string]*FantasyContent
+	lastGetURL string
+	count      int
+}
+
+func (m *mockedRoutingContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	m.lastGetURL = url
+	m.count++
+	for key, content := range m.content {
+		if strings.Contains(url, key) {
+			return content, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *mockedRoutingContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	return m.Get(ctx, url)
+}
+
+func (m *mockedRoutingContentProvider) RequestCount() int {
+	return m.count
+}
+
+func (m *mockedRoutingContentProvider) RequestCountByResource() map[string]int {
+	return map[string]int{}
+}
+
+var gamesXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/games;game_codes=nfl;seasons=2023" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="27.379035949707ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <games count="1">
+    <game>
+      <game_key>423</game_key>
+      <game_id>423</game_id>
+      <name>Football</name>
+      <code>nfl</code>
+      <type>full</type>
+      <url>https://football.fantasysports.yahoo.com/f1</url>
+      <season>2023</season>
+      <is_registration_over>1</is_registration_over>
+      <is_game_over>0</is_game_over>
+      <is_offseason>0</is_offseason>
+    </game>
+  </games>
+</fantasy_content>`
@@ -0,0 +1,181 @@
+package goff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+//
+// JSON Content
+//
+
+// jsonFormatParam is the query parameter that asks Yahoo to respond with JSON
+// instead of XML.
+const jsonFormatParam = "format=json"
+
+// jsonIndexPattern matches the keys Yahoo uses to hold the items of a
+// collection in a JSON response, for example "0" in
+// {"teams": {"0": {"team": ...}, "count": 1}}.
+var jsonIndexPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// jsonContentProvider implements ContentProvider by requesting JSON responses
+// from an httpAPIClient and normalizing them into the same data returned by
+// xmlContentProvider.
+type jsonContentProvider struct {
+	// Makes HTTP requests to the API
+	client httpAPIClient
+}
+
+func (p *jsonContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	response, err := p.client.Get(ctx, jsonURL(url))
+
+	if err != nil {
+		return nil, err
+	}
+	return readContent(response, false, unmarshalJSONContent)
+}
+
+// Send allows an empty response, since Yahoo does not always describe the
+// content that was changed. The body of the request is still sent as XML.
+func (p *jsonContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	response, err := p.client.Send(ctx, method, jsonURL(url), body)
+
+	if err != nil {
+		return nil, err
+	}
+	return readContent(response, true, unmarshalJSONContent)
+}
+
+func (p *jsonContentProvider) RequestCount() int {
+	return p.client.RequestCount()
+}
+
+func (p *jsonContentProvider) RequestCountByResource() map[string]int {
+	return p.client.RequestCountByResource()
+}
+
+// jsonURL adds the parameter requesting a JSON response to the given URL.
+func jsonURL(url string) string {
+	if strings.Contains(url, "?") {
+		return url + "&" + jsonFormatParam
+	}
+	return url + "?" + jsonFormatParam
+}
+
+// unmarshalJSONContent parses a JSON response from Yahoo into the given
+// value using its XML struct tags.
+//
+// Yahoo's JSON mirrors its XML, but splits resources into arrays of parts and
+// stores the items of collections under numbered keys. For example, a league
+// with a single team looks like:
+//
+//    {"league": [{"league_key": "223.l.431"},
+//                {"teams": {"0": {"team": [[{"team_key": "223.l.431.t.1"}]]},
+//                           "count": 1}}]}
+//
+// This is converted back into the equivalent XML elements, where the parts of
+// an array become children of the enclosing element and numbered keys are
+// dropped, before being decoded:
+//
+//    <league>
+//      <league_key>223.l.431</league_key>
+//      <teams>
+//        <team><team_key>223.l.431.t.1</team_key></team>
+//        <count>1</count>
+//      </teams>
+//    </league>
+//
+func unmarshalJSONContent(bits []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(bits))
+	decoder.UseNumber()
+
+	converter := &jsonConverter{decoder: decoder}
+	if err := converter.convert(""); err != nil {
+		return fmt.Errorf("invalid JSON content: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("invalid JSON content: unexpected data after content")
+	}
+
+	return xml.NewTokenDecoder(&xmlTokenList{tokens: converter.tokens}).Decode(v)
+}
+
+// jsonConverter converts a JSON response into the XML tokens of the
+// equivalent XML response.
+type jsonConverter struct {
+	decoder *json.Decoder
+	tokens  []xml.Token
+}
+
+// convert reads the next JSON value and adds it to the tokens as an element
+// with the given name. Objects and arrays without a name only add their
+// contents to the enclosing element.
+func (c *jsonConverter) convert(name string) error {
+	token, err := c.decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	c.start(name)
+	switch value := token.(type) {
+	case json.Delim:
+		for c.decoder.More() {
+			child := ""
+			if value == '{' {
+				key, err := c.decoder.Token()
+				if err != nil {
+					return err
+				}
+				child = key.(string)
+				if jsonIndexPattern.MatchString(child) {
+					child = ""
+				}
+			}
+			if err := c.convert(child); err != nil {
+				return err
+			}
+		}
+		// Consume the closing delimiter
+		if _, err := c.decoder.Token(); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		c.tokens = append(c.tokens, xml.CharData(fmt.Sprint(value)))
+	}
+	c.end(name)
+	return nil
+}
+
+func (c *jsonConverter) start(name string) {
+	if name != "" {
+		c.tokens = append(c.tokens, xml.StartElement{Name: xml.Name{Local: name}})
+	}
+}
+
+func (c *jsonConverter) end(name string) {
+	if name != "" {
+		c.tokens = append(c.tokens, xml.EndElement{Name: xml.Name{Local: name}})
+	}
+}
+
+// xmlTokenList implements xml.TokenReader for a list of tokens.
+type xmlTokenList struct {
+	tokens []xml.Token
+	index  int
+}
+
+func (l *xmlTokenList) Token() (xml.Token, error) {
+	if l.index >= len(l.tokens) {
+		return nil, io.EOF
+	}
+	token := l.tokens[l.index]
+	l.index++
+	return token, nil
+}
@@ -0,0 +1,180 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+//
+// Test jsonContentProvider
+//
+
+// jsonFixtures are JSON responses from Yahoo paired with the XML response for
+// the same content.
+var jsonFixtures = []struct {
+	name string
+	xml  string
+	json string
+}{
+	{name: "team", xml: teamXMLContent, json: teamJSONContent},
+	{name: "league", xml: leagueXMLContent, json: leagueJSONContent},
+	{name: "daily roster", xml: dailyRosterXMLContent, json: dailyRosterJSONContent},
+	{name: "scoreboard", xml: scoreboardXMLContent, json: scoreboardJSONContent},
+	{name: "player stats", xml: playerStatsXMLContent, json: playerStatsJSONContent},
+	{name: "stat categories", xml: statCategoriesXMLContent, json: statCategoriesJSONContent},
+}
+
+func TestJSONContentProviderParity(t *testing.T) {
+	for _, fixture := range jsonFixtures {
+		xmlProvider := &xmlContentProvider{
+			client: &countingHTTPApiClient{
+				client: &mockHTTPClient{Response: mockResponse(fixture.xml)},
+			},
+		}
+		expected, err := xmlProvider.Get(context.Background(), "http://example.com")
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s XML: %s", fixture.name, err)
+		}
+
+		jsonProvider := &jsonContentProvider{
+			client: &countingHTTPApiClient{
+				client: &mockHTTPClient{Response: mockResponse(fixture.json)},
+			},
+		}
+		actual, err := jsonProvider.Get(context.Background(), "http://example.com")
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s JSON: %s", fixture.name, err)
+		}
+
+		// JSON responses have no XML namespace to record
+		expected.XMLName = actual.XMLName
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("JSON content does not match XML content for %s\n\t"+
+				"expected: %+v\n\tactual: %+v",
+				fixture.name,
+				expected,
+				actual)
+		}
+	}
+}
+
+func TestJSONContentProviderGet(t *testing.T) {
+	httpClient := &mockHTTPClient{Response: mockResponse(teamJSONContent)}
+	provider := &jsonContentProvider{
+		client: &countingHTTPApiClient{client: httpClient},
+	}
+
+	content, err := provider.Get(
+		context.Background(),
+		"http://example.com/team/223.l.431.t.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	assertStringEquals(t,
+		"http://example.com/team/223.l.431.t.1?format=json",
+		httpClient.LastURL)
+	assertStringEquals(t, expectedTeam.TeamKey, content.Team.TeamKey)
+	assertFloatEquals(t, expectedTeam.TeamPoints.Total, content.Team.TeamPoints.Total)
+	assertIntEquals(t, 1, provider.RequestCount())
+	assertIntEquals(t, 1, provider.RequestCountByResource()["team"])
+}
+
+func TestJSONContentProviderSend(t *testing.T) {
+	httpClient := &mockHTTPClient{Response: mockResponse("")}
+	provider := &jsonContentProvider{
+		client: &countingHTTPApiClient{client: httpClient},
+	}
+
+	content, err := provider.Send(
+		context.Background(),
+		http.MethodPut,
+		"http://example.com/team/223.l.431.t.1/roster",
+		[]byte("<fantasy_content/>"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if content == nil {
+		t.Fatal("no content returned for empty response")
+	}
+
+	assertStringEquals(t, http.MethodPut, httpClient.LastRequest.Method)
+	assertStringEquals(t,
+		"http://example.com/team/223.l.431.t.1/roster?format=json",
+		httpClient.LastURL)
+	assertStringEquals(t,
+		"application/xml",
+		httpClient.LastRequest.Header.Get("Content-Type"))
+}
+
+func TestJSONContentProviderInvalidContent(t *testing.T) {
+	tests := []string{
+		"",
+		"<fantasy_content/>",
+		`{"fantasy_content": {"team": [`,
+		`{"fantasy_content": {}} {}`,
+		`{"fantasy_content": {"team": {"team_points": {"total": "abc"}}}}`,
+	}
+
+	for _, test := range tests {
+		provider := &jsonContentProvider{
+			client: &countingHTTPApiClient{
+				client: &mockHTTPClient{Response: mockResponse(test)},
+			},
+		}
+
+		content, err := provider.Get(context.Background(), "http://example.com")
+		if err == nil {
+			t.Fatalf("no error returned for content %q: %+v", test, content)
+		}
+	}
+}
+
+func TestJSONContentProviderAPIError(t *testing.T) {
+	response := mockResponse(errorJSONContent)
+	response.StatusCode = http.StatusNotFound
+	provider := &jsonContentProvider{
+		client: &countingHTTPApiClient{
+			client: &mockHTTPClient{Response: response},
+		},
+	}
+
+	_, err := provider.Get(context.Background(), "http://example.com")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("APIError not returned: %+v", err)
+	}
+	assertStringEquals(t, "Invalid league key.", apiErr.Description)
+}
+
+func TestJSONURL(t *testing.T) {
+	tests := map[string]string{
+		"http://example.com/league/223.l.431":              "http://example.com/league/223.l.431?format=json",
+		"http://example.com/league/223.l.431;out=settings": "http://example.com/league/223.l.431;out=settings?format=json",
+		"http://example.com/league/223.l.431?a=b":          "http://example.com/league/223.l.431?a=b&format=json",
+	}
+
+	for url, expected := range tests {
+		assertStringEquals(t, expected, jsonURL(url))
+	}
+}
+
+//
+// Test Data
+//
+
+var teamJSONContent = `{"fantasy_content":{"xml:lang":"en-US","yahoo:uri":"/fantasy/v2/team/223.l.431.t.1","team":[[{"team_key":"223.l.431.t.1"},{"team_id":"1"},{"name":"Team Name"},[],{"url":"http://football.fantasysports.yahoo.com/archive/pnfl/2009/431/1"},{"team_logos":[{"team_logo":{"size":"medium","url":"http://example.com/logo.png"}}]},[],{"division_id":"2"},{"faab_balance":"22"},{"managers":[{"manager":{"manager_id":"13","nickname":"Nickname","guid":"1234567890"}}]}],{"team_points":{"coverage_type":"week","week":"16","total":"123.450000"},"team_projected_points":{"coverage_type":"week","week":16,"total":"543.210000"}}],"time":"426.26690864563ms","copyright":"Data provided by Yahoo! and STATS, LLC"}}`
+
+var leagueJSONContent = `{"fantasy_content":{"xml:lang":"en-US","yahoo:uri":"/fantasy/v2/league/223.l.431","league":[{"league_key":"223.l.431","league_id":"341","name":"League Name","url":"http://football.fantasysports.yahoo.com/archive/pnfl/2009/431","draft_status":"postdraft","num_teams":14,"edit_key":17,"weekly_deadline":"","league_update_timestamp":"1262595518","scoring_type":"head","current_week":16,"start_week":"1","end_week":"16","is_finished":1}],"time":"181.80584907532ms","copyright":"Data provided by Yahoo! and STATS, LLC"}}`
+
+var dailyRosterJSONContent = `{"fantasy_content":{"xml:lang":"en-US","yahoo:uri":"/fantasy/v2/team/422.l.1.t.1/roster;date=2023-05-04","team":[[{"team_key":"422.l.1.t.1"},{"team_id":"1"},{"name":"Team Name"}],{"roster":{"coverage_type":"date","date":"2023-05-04","0":{"players":{"0":{"player":[[{"player_key":"422.p.9001"},{"player_id":"9001"},{"name":{"full":"Firstname Lastname","first":"Firstname","last":"Lastname"}},{"display_position":"SS"}],{"selected_position":[{"coverage_type":"date"},{"date":"2023-05-04"},{"position":"SS"}]},{"player_points":{"coverage_type":"date","date":"2023-05-04","total":"12.5"}}]},"count":1}}}}],"time":"31.862020492554ms","copyright":"Data provided by Yahoo! and STATS, LLC","refresh_rate":"60"}}`
+
+var scoreboardJSONContent = `{"fantasy_content":{"xml:lang":"en-US","yahoo:uri":"/fantasy/v2/league/314.l.1/scoreboard;week=15","league":[{"league_key":"314.l.1","league_id":"1","name":"League Name"},{"scoreboard":{"0":{"matchups":{"0":{"matchup":{"week":"15","week_start":"2013-12-10","week_end":"2013-12-16","status":"postevent","is_playoffs":"1","is_consolation":"0","is_tied":0,"winner_team_key":"314.l.1.t.1","stat_winners":[{"stat_winner":{"stat_id":"7","winner_team_key":"314.l.1.t.1"}},{"stat_winner":{"stat_id":"8","is_tied":"1"}}],"0":{"teams":{"0":{"team":[[{"team_key":"314.l.1.t.1"},{"team_id":"1"},{"name":"Team One"}],{"win_probability":0.75,"team_stats":{"coverage_type":"week","week":"15","stats":[{"stat":{"stat_id":"7","value":"21"}}]},"team_points":{"coverage_type":"week","week":"15","total":"110.5"},"team_projected_points":{"coverage_type":"week","week":"15","total":"98.25"}}]},"1":{"team":[[{"team_key":"314.l.1.t.2"},{"team_id":"2"},{"name":"Team Two"}],{"win_probability":0.25,"team_points":{"coverage_type":"week","week":"15","total":"90"}}]},"count":2}}}},"count":1}},"week":"15"}}],"time":"111.01317405701ms","copyright":"Data provided by Yahoo! and STATS, LLC","refresh_rate":"60"}}`
+
+var playerStatsJSONContent = `{"fantasy_content":{"xml:lang":"en-US","yahoo:uri":"/fantasy/v2/league/314.l.431/players;player_keys=314.p.8261/stats;type=week;week=1","league":[{"league_key":"314.l.431","league_id":"431","name":"League Name"},{"players":{"0":{"player":[[{"player_key":"314.p.8261"},{"player_id":"8261"},{"name":{"full":"Firstname Lastname","first":"Firstname","last":"Lastname"}},{"display_position":"QB"}],{"player_stats":{"coverage_type":"week","week":"1","stats":[{"stat":{"stat_id":"4","value":"312"}},{"stat":{"stat_id":"5","value":"2"}},{"stat":{"stat_id":"11","value":"-"}}]},"player_points":{"coverage_type":"week","week":"1","total":"20.48"}}]},"count":1}}],"time":"61.728954315186ms","copyright":"Data provided by Yahoo! and STATS, LLC","refresh_rate":"60"}}`
+
+var statCategoriesJSONContent = `{"fantasy_content":{"xml:lang":"en-US","yahoo:uri":"/fantasy/v2/game/314/stat_categories","game":[{"game_key":"314","game_id":"314","name":"Football","code":"nfl","type":"full","season":"2013"},{"stat_categories":{"stats":[{"stat":{"stat_id":4,"name":"Passing Yards","display_name":"Pass Yds","sort_order":"1","position_types":[{"position_type":"O"}]}},{"stat":{"stat_id":11,"name":"Receptions","display_name":"Rec","sort_order":"1","position_types":[{"position_type":"O"}]}}]}}],"time":"21.064043045044ms","copyright":"Data provided by Yahoo! and STATS, LLC","refresh_rate":"60"}}`
@@ -0,0 +1,116 @@
+package goff
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+//
+// Lineups
+//
+
+// rosterRequest is the body of a request to change the positions of players
+// on a team's roster.
+type rosterRequest struct {
+	XMLName xml.Name      `xml:"fantasy_content"`
+	Roster  rosterChanges `xml:"roster"`
+}
+
+// rosterChanges are the new positions of players for a week or date.
+type rosterChanges struct {
+	CoverageType string           `xml:"coverage_type"`
+	Week         int              `xml:"week,omitempty"`
+	Date         string           `xml:"date,omitempty"`
+	Players      []positionChange `xml:"players>player"`
+}
+
+// positionChange moves a single player to a new position.
+type positionChange struct {
+	PlayerKey string `xml:"player_key"`
+	Position  string `xml:"position"`
+}
+
+// SetLineup moves players on a team's roster for the given week. The given
+// positions map player keys to their new position, such as "QB" or "BN" for
+// the bench. Players that are not given keep their current position.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) SetLineup(teamKey string, week int, positions map[string]string) error {
+	return c.SetLineupContext(context.Background(), teamKey, week, positions)
+}
+
+// SetLineupContext moves players on a team's roster for the given week using
+// the given context for the API request.
+func (c *Client) SetLineupContext(ctx context.Context, teamKey string, week int, positions map[string]string) error {
+	return c.setLineup(ctx, teamKey, rosterChanges{
+		CoverageType: WeekCoverage,
+		Week:         week,
+		Players:      positionChanges(positions),
+	})
+}
+
+// SetLineupForDate moves players on a team's roster for the given date. Use
+// this for sports with daily lineups, such as baseball.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) SetLineupForDate(teamKey string, date time.Time, positions map[string]string) error {
+	return c.SetLineupForDateContext(context.Background(), teamKey, date, positions)
+}
+
+// SetLineupForDateContext moves players on a team's roster for the given date
+// using the given context for the API request.
+func (c *Client) SetLineupForDateContext(ctx context.Context, teamKey string, date time.Time, positions map[string]string) error {
+	return c.setLineup(ctx, teamKey, rosterChanges{
+		CoverageType: DateCoverage,
+		Date:         date.Format(DateFormat),
+		Players:      positionChanges(positions),
+	})
+}
+
+// setLineup sends the roster changes for the given team.
+func (c *Client) setLineup(ctx context.Context, teamKey string, changes rosterChanges) error {
+	if len(changes.Players) == 0 {
+		return fmt.Errorf("no positions given for team=%s", teamKey)
+	}
+
+	body, err := marshalRequest(&rosterRequest{Roster: changes})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.SendFantasyContentContext(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf("%s/team/%s/roster", c.baseURL(), teamKey),
+		body)
+	return err
+}
+
+// positionChanges converts a map of player keys to positions into a list
+// sorted by player key.
+func positionChanges(positions map[string]string) []positionChange {
+	changes := make([]positionChange, 0, len(positions))
+	for playerKey, position := range positions {
+		changes = append(changes, positionChange{
+			PlayerKey: playerKey,
+			Position:  position,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].PlayerKey < changes[j].PlayerKey
+	})
+	return changes
+}
+
+// marshalRequest converts the body of a request to XML.
+func marshalRequest(request interface{}) ([]byte, error) {
+	bits, err := xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), bits...), nil
+}
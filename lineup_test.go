@@ -0,0 +1,103 @@
+package goff
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+//
+// Test SetLineup
+//
+
+func TestSetLineup(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	err := client.SetLineup("223.l.431.t.1", 13, map[string]string{
+		"223.p.8261": "WR",
+		"223.p.1234": "BN",
+	})
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertStringEquals(t, http.MethodPut, provider.lastSendMethod)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/team/223.l.431.t.1/roster",
+		provider.lastGetURL)
+
+	var request rosterRequest
+	if err := xml.Unmarshal(provider.lastSendBody, &request); err != nil {
+		t.Fatalf("Unable to parse request body: %s\n\tbody: %s",
+			err,
+			provider.lastSendBody)
+	}
+
+	roster := request.Roster
+	assertStringEquals(t, WeekCoverage, roster.CoverageType)
+	assertIntEquals(t, 13, roster.Week)
+	assertStringEquals(t, "", roster.Date)
+	if len(roster.Players) != 2 {
+		t.Fatalf("Unexpected players sent: %+v", roster.Players)
+	}
+	assertStringEquals(t, "223.p.1234", roster.Players[0].PlayerKey)
+	assertStringEquals(t, "BN", roster.Players[0].Position)
+	assertStringEquals(t, "223.p.8261", roster.Players[1].PlayerKey)
+	assertStringEquals(t, "WR", roster.Players[1].Position)
+}
+
+func TestSetLineupForDate(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	date := time.Date(2023, time.May, 4, 0, 0, 0, 0, time.UTC)
+	err := client.SetLineupForDate("422.l.1.t.1", date, map[string]string{
+		"422.p.9001": "SS",
+	})
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	var request rosterRequest
+	if err := xml.Unmarshal(provider.lastSendBody, &request); err != nil {
+		t.Fatalf("Unable to parse request body: %s\n\tbody: %s",
+			err,
+			provider.lastSendBody)
+	}
+
+	roster := request.Roster
+	assertStringEquals(t, DateCoverage, roster.CoverageType)
+	assertStringEquals(t, "2023-05-04", roster.Date)
+	assertIntEquals(t, 0, roster.Week)
+	assertStringEquals(t, "422.p.9001", roster.Players[0].PlayerKey)
+	assertStringEquals(t, "SS", roster.Players[0].Position)
+}
+
+func TestSetLineupNoPositions(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	err := client.SetLineup("223.l.431.t.1", 13, map[string]string{})
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+	assertIntEquals(t, 0, provider.count)
+}
+
+func TestSetLineupError(t *testing.T) {
+	expected := &APIError{StatusCode: http.StatusBadRequest}
+	client := mockClient(nil, expected)
+
+	err := client.SetLineup("223.l.431.t.1", 13, map[string]string{
+		"223.p.8261": "WR",
+	})
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			expected,
+			err)
+	}
+}
@@ -0,0 +1,45 @@
+package goff
+
+//
+// Matchups
+//
+
+// IsFinished returns whether all games in the matchup have been played.
+func (m *Matchup) IsFinished() bool {
+	return m.Status == MatchupPostEvent
+}
+
+// Winner returns the team that won the matchup. False is returned if the
+// matchup is not finished, ended in a tie, or the winner is not one of the
+// matchup's teams.
+func (m *Matchup) Winner() (*Team, bool) {
+	if !m.IsFinished() || m.IsTied || m.WinnerTeamKey == "" {
+		return nil, false
+	}
+	return m.Team(m.WinnerTeamKey)
+}
+
+// Team returns the team in the matchup with the given key.
+func (m *Matchup) Team(teamKey string) (*Team, bool) {
+	for i := range m.Teams {
+		if m.Teams[i].TeamKey == teamKey {
+			return &m.Teams[i], true
+		}
+	}
+	return nil, false
+}
+
+// StatWins returns the number of stat categories won by each team in the
+// matchup, keyed by team key. Tied categories are not counted.
+func (m *Matchup) StatWins() map[string]int {
+	wins := make(map[string]int, len(m.Teams))
+	for _, team := range m.Teams {
+		wins[team.TeamKey] = 0
+	}
+	for _, winner := range m.StatWinners {
+		if !winner.IsTied && winner.WinnerTeamKey != "" {
+			wins[winner.WinnerTeamKey]++
+		}
+	}
+	return wins
+}
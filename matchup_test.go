@@ -0,0 +1,223 @@
+package goff
+
+import (
+	"context"
+	"testing"
+)
+
+//
+// Test Matchup
+//
+
+func TestMatchupWinner(t *testing.T) {
+	matchup := Matchup{
+		Status:        MatchupPostEvent,
+		WinnerTeamKey: "314.l.1.t.2",
+		Teams: []Team{
+			Team{TeamKey: "314.l.1.t.1"},
+			Team{TeamKey: "314.l.1.t.2"},
+		},
+	}
+
+	if !matchup.IsFinished() {
+		t.Fatal("Matchup was not finished")
+	}
+	winner, ok := matchup.Winner()
+	if !ok {
+		t.Fatal("No winner returned")
+	}
+	assertStringEquals(t, "314.l.1.t.2", winner.TeamKey)
+}
+
+func TestMatchupWinnerNotFinished(t *testing.T) {
+	for _, status := range []string{MatchupPreEvent, MatchupMidEvent} {
+		matchup := Matchup{
+			Status:        status,
+			WinnerTeamKey: "314.l.1.t.2",
+			Teams:         []Team{Team{TeamKey: "314.l.1.t.2"}},
+		}
+
+		if matchup.IsFinished() {
+			t.Fatalf("Matchup was finished with status %s", status)
+		}
+		if _, ok := matchup.Winner(); ok {
+			t.Fatalf("Winner returned for matchup with status %s", status)
+		}
+	}
+}
+
+func TestMatchupWinnerTied(t *testing.T) {
+	matchup := Matchup{
+		Status: MatchupPostEvent,
+		IsTied: true,
+		Teams: []Team{
+			Team{TeamKey: "314.l.1.t.1", TeamPoints: Points{Total: 100}},
+			Team{TeamKey: "314.l.1.t.2", TeamPoints: Points{Total: 100}},
+		},
+	}
+
+	if _, ok := matchup.Winner(); ok {
+		t.Fatal("Winner returned for tied matchup")
+	}
+}
+
+func TestMatchupTeam(t *testing.T) {
+	matchup := Matchup{
+		Teams: []Team{
+			Team{TeamKey: "314.l.1.t.1", Name: "One"},
+			Team{TeamKey: "314.l.1.t.2", Name: "Two"},
+		},
+	}
+
+	team, ok := matchup.Team("314.l.1.t.2")
+	if !ok {
+		t.Fatal("Team not found")
+	}
+	assertStringEquals(t, "Two", team.Name)
+
+	if _, ok := matchup.Team("314.l.1.t.3"); ok {
+		t.Fatal("Team found that is not in matchup")
+	}
+}
+
+func TestMatchupStatWins(t *testing.T) {
+	matchup := Matchup{
+		StatWinners: []StatWinner{
+			StatWinner{StatID: 7, WinnerTeamKey: "314.l.1.t.1"},
+			StatWinner{StatID: 8, WinnerTeamKey: "314.l.1.t.1"},
+			StatWinner{StatID: 12, WinnerTeamKey: "314.l.1.t.2"},
+			StatWinner{StatID: 13, IsTied: true},
+		},
+		Teams: []Team{
+			Team{TeamKey: "314.l.1.t.1"},
+			Team{TeamKey: "314.l.1.t.2"},
+			Team{TeamKey: "314.l.1.t.3"},
+		},
+	}
+
+	wins := matchup.StatWins()
+	assertIntEquals(t, 3, len(wins))
+	assertIntEquals(t, 2, wins["314.l.1.t.1"])
+	assertIntEquals(t, 1, wins["314.l.1.t.2"])
+	assertIntEquals(t, 0, wins["314.l.1.t.3"])
+}
+
+func TestXMLContentProviderGetScoreboard(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(scoreboardXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	matchups := content.League.Scoreboard.Matchups
+	if len(matchups) != 1 {
+		t.Fatalf("Unexpected matchups parsed: %+v", matchups)
+	}
+
+	matchup := matchups[0]
+	assertIntEquals(t, 15, matchup.Week)
+	assertStringEquals(t, "2013-12-10", matchup.WeekStart)
+	assertStringEquals(t, "2013-12-16", matchup.WeekEnd)
+	assertStringEquals(t, MatchupPostEvent, matchup.Status)
+	assertBoolEquals(t, true, matchup.IsPlayoffs)
+	assertBoolEquals(t, false, matchup.IsConsolation)
+	assertBoolEquals(t, false, matchup.IsTied)
+	assertStringEquals(t, "314.l.1.t.1", matchup.WinnerTeamKey)
+
+	if len(matchup.StatWinners) != 2 {
+		t.Fatalf("Unexpected stat winners parsed: %+v", matchup.StatWinners)
+	}
+	assertIntEquals(t, 7, matchup.StatWinners[0].StatID)
+	assertStringEquals(t, "314.l.1.t.1", matchup.StatWinners[0].WinnerTeamKey)
+	assertBoolEquals(t, true, matchup.StatWinners[1].IsTied)
+
+	winner, ok := matchup.Winner()
+	if !ok {
+		t.Fatal("No winner returned")
+	}
+	assertFloatEquals(t, 0.75, winner.WinProbability)
+	assertFloatEquals(t, 110.5, winner.TeamPoints.Total)
+	assertFloatEquals(t, 98.25, winner.TeamProjectedPoints.Total)
+	if len(winner.TeamStats) != 1 {
+		t.Fatalf("Unexpected team stats parsed: %+v", winner.TeamStats)
+	}
+	assertStringEquals(t, "21", winner.TeamStats[0].Value)
+}
+
+var scoreboardXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/314.l.1/scoreboard;week=15" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="111.01317405701ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <league>
+    <league_key>314.l.1</league_key>
+    <league_id>1</league_id>
+    <name>League Name</name>
+    <scoreboard>
+      <week>15</week>
+      <matchups count="1">
+        <matchup>
+          <week>15</week>
+          <week_start>2013-12-10</week_start>
+          <week_end>2013-12-16</week_end>
+          <status>postevent</status>
+          <is_playoffs>1</is_playoffs>
+          <is_consolation>0</is_consolation>
+          <is_tied>0</is_tied>
+          <winner_team_key>314.l.1.t.1</winner_team_key>
+          <stat_winners count="2">
+            <stat_winner>
+              <stat_id>7</stat_id>
+              <winner_team_key>314.l.1.t.1</winner_team_key>
+            </stat_winner>
+            <stat_winner>
+              <stat_id>8</stat_id>
+              <is_tied>1</is_tied>
+            </stat_winner>
+          </stat_winners>
+          <teams count="2">
+            <team>
+              <team_key>314.l.1.t.1</team_key>
+              <team_id>1</team_id>
+              <name>Team One</name>
+              <win_probability>0.75</win_probability>
+              <team_stats>
+                <coverage_type>week</coverage_type>
+                <week>15</week>
+                <stats>
+                  <stat>
+                    <stat_id>7</stat_id>
+                    <value>21</value>
+                  </stat>
+                </stats>
+              </team_stats>
+              <team_points>
+                <coverage_type>week</coverage_type>
+                <week>15</week>
+                <total>110.5</total>
+              </team_points>
+              <team_projected_points>
+                <coverage_type>week</coverage_type>
+                <week>15</week>
+                <total>98.25</total>
+              </team_projected_points>
+            </team>
+            <team>
+              <team_key>314.l.1.t.2</team_key>
+              <team_id>2</team_id>
+              <name>Team Two</name>
+              <win_probability>0.25</win_probability>
+              <team_points>
+                <coverage_type>week</coverage_type>
+                <week>15</week>
+                <total>90</total>
+              </team_points>
+            </team>
+          </teams>
+        </matchup>
+      </matchups>
+    </scoreboard>
+  </league>
+</fantasy_content>`
@@ -0,0 +1,139 @@
+package goff
+
+import (
+	"net/http"
+	"time"
+)
+
+//
+// Client Options
+//
+
+// Option configures a Client created by NewClient or NewCachedClient.
+type Option func(*clientOptions)
+
+// Middleware wraps the HTTPClient used to make requests, for example to log
+// or modify each request before it is sent.
+type Middleware func(next HTTPClient) HTTPClient
+
+// HTTPClientFunc adapts a function to the HTTPClient interface.
+type HTTPClientFunc func(request *http.Request) (*http.Response, error)
+
+// Do calls f(request).
+func (f HTTPClientFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// clientOptions is the configuration built from the Options given when
+// creating a Client.
+type clientOptions struct {
+	baseURL     string
+	userAgent   string
+	timeout     time.Duration
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	cache       Cache
+	middleware  []Middleware
+	jsonFormat  bool
+	cacheUser   string
+}
+
+// newClientOptions applies the given options on top of the default
+// configuration.
+func newClientOptions(options []Option) *clientOptions {
+	o := &clientOptions{
+		baseURL:     YahooBaseURL,
+		retryPolicy: DefaultRetryPolicy(),
+	}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
+// WithBaseURL sets the base URL used by the client's convenience functions
+// in place of YahooBaseURL, for example to send requests through a proxy or to
+// a local server during testing.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits how long each attempt of a request, including reading
+// the response, can take. Retried requests get the full timeout for every
+// attempt. A duration of zero means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCache checks and updates the given Cache when retrieving fantasy
+// content. Concurrent requests for the same URL that miss the cache share a
+// single request to the API.
+//
+// See NewLRUCache
+func WithCache(cache Cache) Option {
+	return func(o *clientOptions) {
+		o.cache = cache
+	}
+}
+
+// WithCacheUser scopes the content the client caches to the given user, for
+// example the GUID of the logged in user or a fingerprint of their access
+// token. Clients that share a Cache on behalf of different users must each be
+// given a different user so they never share content.
+//
+// Without a user, content for login-relative URLs, such as
+// ".../users;use_login=1/games", is never cached, nor is any content with
+// teams, such as teams, standings, or scoreboards, since Yahoo flags the
+// teams and managers of the logged in user.
+func WithCacheUser(user string) Option {
+	return func(o *clientOptions) {
+		o.cacheUser = user
+	}
+}
+
+// WithMiddleware wraps the HTTPClient given to NewClient with the given
+// middleware. The first middleware given is the first to see each request.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests. Use a policy
+// with MaxAttempts set to 1 to disable retries.
+//
+// See DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithRateLimiter limits the rate of requests made by the client using the
+// given RateLimiter. Every attempt of a request, including retries, waits for
+// the limiter. Requests served from a cache do not.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithJSONFormat requests content from Yahoo as JSON instead of XML. The
+// content is decoded into the same FantasyContent either way, but JSON
+// responses can be smaller and faster to parse for some resources.
+func WithJSONFormat() Option {
+	return func(o *clientOptions) {
+		o.jsonFormat = true
+	}
+}
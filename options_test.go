@@ -0,0 +1,203 @@
+package goff
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+//
+// Test Options
+//
+
+func TestNewClientDefaultOptions(t *testing.T) {
+	client := NewClient(&mockHTTPClient{})
+
+	assertStringEquals(t, YahooBaseURL, client.BaseURL)
+	if _, ok := client.Provider.(*xmlContentProvider); !ok {
+		t.Fatalf("Unexpected provider for client without cache: %T",
+			client.Provider)
+	}
+}
+
+func TestClientBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"":                       YahooBaseURL,
+		"http://127.0.0.1:8080":  "http://127.0.0.1:8080",
+		"http://127.0.0.1:8080/": "http://127.0.0.1:8080",
+	}
+
+	for baseURL, expected := range tests {
+		client := &Client{BaseURL: baseURL}
+		assertStringEquals(t, expected, client.baseURL())
+	}
+}
+
+func TestWithJSONFormat(t *testing.T) {
+	client := NewClient(&mockHTTPClient{}, WithJSONFormat())
+	if _, ok := client.Provider.(*jsonContentProvider); !ok {
+		t.Fatalf("Unexpected provider for client with JSON format: %T",
+			client.Provider)
+	}
+
+	client = NewClient(&mockHTTPClient{}, WithJSONFormat(), WithCache(&mockedCache{}))
+	provider, ok := client.Provider.(*cachedContentProvider)
+	if !ok {
+		t.Fatalf("Unexpected provider for client with cache: %T",
+			client.Provider)
+	}
+	if _, ok := provider.delegate.(*jsonContentProvider); !ok {
+		t.Fatalf("Unexpected delegate for cached client with JSON format: %T",
+			provider.delegate)
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	requestedPath := ""
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requestedPath = r.URL.Path
+			w.Write([]byte(leagueXMLContent))
+		}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), WithBaseURL(server.URL+"/fantasy/v2"))
+	league, err := client.GetLeagueMetadata(expectedLeague.LeagueKey)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertLeaguesEqual(t, []League{expectedLeague}, []League{*league})
+	assertStringEquals(
+		t,
+		"/fantasy/v2/league/"+expectedLeague.LeagueKey+";out=settings",
+		requestedPath)
+}
+
+func TestWithUserAgent(t *testing.T) {
+	userAgent := ""
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.Header.Get("User-Agent")
+			w.Write([]byte(leagueXMLContent))
+		}))
+	defer server.Close()
+
+	expected := "goff-test/1.0"
+	client := NewClient(
+		server.Client(),
+		WithBaseURL(server.URL),
+		WithUserAgent(expected))
+	if _, err := client.GetLeagueMetadata("123"); err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertStringEquals(t, expected, userAgent)
+}
+
+func TestWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+	defer server.Close()
+
+	client := NewClient(
+		server.Client(),
+		WithBaseURL(server.URL),
+		WithTimeout(10*time.Millisecond),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+
+	start := time.Now()
+	_, err := client.GetLeagueMetadata("123")
+	if err == nil {
+		t.Fatal("Client did not return error when request timed out")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Request not canceled after timeout, elapsed: %s", elapsed)
+	}
+	assertIntEquals(t, 2, client.RequestCount())
+}
+
+func TestWithTimeoutReadsBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(leagueXMLContent))
+		}))
+	defer server.Close()
+
+	client := NewClient(
+		server.Client(),
+		WithBaseURL(server.URL),
+		WithTimeout(time.Second))
+
+	league, err := client.GetLeagueMetadata(expectedLeague.LeagueKey)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+	assertLeaguesEqual(t, []League{expectedLeague}, []League{*league})
+}
+
+func TestWithCache(t *testing.T) {
+	cache := mockCache()
+	client := NewClient(&mockHTTPClient{}, WithCache(cache))
+
+	provider, ok := client.Provider.(*cachedContentProvider)
+	if !ok {
+		t.Fatalf("Unexpected provider for client with cache: %T",
+			client.Provider)
+	}
+
+	if provider.cache != cache {
+		t.Fatalf("Unexpected cache used by client\n\texpected: %+v\n\t"+
+			"actual: %+v",
+			cache,
+			provider.cache)
+	}
+}
+
+func TestWithCacheUser(t *testing.T) {
+	client := NewClient(&mockHTTPClient{}, WithCache(mockCache()), WithCacheUser("guid"))
+
+	provider, ok := client.Provider.(*cachedContentProvider)
+	if !ok {
+		t.Fatalf("Unexpected provider for client with cache: %T",
+			client.Provider)
+	}
+	assertStringEquals(t, "guid", provider.user)
+}
+
+func TestWithMiddleware(t *testing.T) {
+	order := []string{}
+	middleware := func(name string) Middleware {
+		return func(next HTTPClient) HTTPClient {
+			return HTTPClientFunc(func(request *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(request)
+			})
+		}
+	}
+
+	httpClient := &mockHTTPClient{Response: mockResponse(leagueXMLContent)}
+	client := NewClient(
+		httpClient,
+		WithMiddleware(middleware("first"), middleware("second")),
+		WithMiddleware(middleware("third")))
+
+	if _, err := client.GetLeagueMetadata("123"); err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	if len(order) != 3 ||
+		order[0] != "first" ||
+		order[1] != "second" ||
+		order[2] != "third" {
+		t.Fatalf("Middleware called in unexpected order: %+v", order)
+	}
+	assertIntEquals(t, 1, httpClient.RequestCount)
+}
@@ -0,0 +1,300 @@
+package goff
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+)
+
+//
+// Player Search
+//
+
+// Player statuses that can be used to filter a PlayerQuery
+const (
+	// AvailablePlayers are all players not on a team, free agents or waivers
+	AvailablePlayers = "A"
+	// FreeAgentPlayers are players that can be added without waivers
+	FreeAgentPlayers = "FA"
+	// WaiverPlayers are players currently on waivers
+	WaiverPlayers = "W"
+	// TakenPlayers are players on a team in the league
+	TakenPlayers = "T"
+	// KeeperPlayers are players kept by a team from the previous season
+	KeeperPlayers = "K"
+)
+
+// Orders that can be used to sort the results of a PlayerQuery
+const (
+	// SortByActualRank sorts players by their rank over the sort period
+	SortByActualRank = "AR"
+	// SortByOverallRank sorts players by their preseason rank
+	SortByOverallRank = "OR"
+	// SortByPoints sorts players by fantasy points over the sort period
+	SortByPoints = "PTS"
+)
+
+// Periods used to sort the results of a PlayerQuery
+const (
+	SortTypeSeason    = "season"
+	SortTypeWeek      = "week"
+	SortTypeLastWeek  = "lastweek"
+	SortTypeLastMonth = "lastmonth"
+)
+
+// maxPlayersPerPage is the most players Yahoo returns for a single request to
+// a players collection.
+const maxPlayersPerPage = 25
+
+// PlayerQuery filters and sorts the players in a league returned by
+// SearchPlayers. Use NewPlayerQuery to create a query and its methods to add
+// filters, for example:
+//
+//    query := goff.NewPlayerQuery().
+//        Status(goff.FreeAgentPlayers).
+//        Position("QB").
+//        Sort(goff.SortByActualRank)
+//
+type PlayerQuery struct {
+	status     string
+	position   string
+	search     string
+	sort       string
+	sortType   string
+	sortSeason string
+	sortWeek   int
+	start      int
+	count      int
+}
+
+// NewPlayerQuery creates a query that matches all players in a league.
+func NewPlayerQuery() *PlayerQuery {
+	return &PlayerQuery{}
+}
+
+// Status only matches players with the given status, for example
+// FreeAgentPlayers.
+func (q *PlayerQuery) Status(status string) *PlayerQuery {
+	q.status = status
+	return q
+}
+
+// Position only matches players eligible at the given position, such as "QB".
+func (q *PlayerQuery) Position(position string) *PlayerQuery {
+	q.position = position
+	return q
+}
+
+// Search only matches players whose name contains the given text.
+func (q *PlayerQuery) Search(search string) *PlayerQuery {
+	q.search = search
+	return q
+}
+
+// Sort orders the matching players, for example SortByActualRank or by the
+// ID of a stat.
+func (q *PlayerQuery) Sort(sort string) *PlayerQuery {
+	q.sort = sort
+	return q
+}
+
+// SortType sets the period used to sort players, for example SortTypeWeek.
+func (q *PlayerQuery) SortType(sortType string) *PlayerQuery {
+	q.sortType = sortType
+	return q
+}
+
+// SortSeason sets the season used to sort players when sorting by
+// SortTypeSeason.
+func (q *PlayerQuery) SortSeason(season string) *PlayerQuery {
+	q.sortSeason = season
+	return q
+}
+
+// SortWeek sets the week used to sort players when sorting by SortTypeWeek.
+func (q *PlayerQuery) SortWeek(week int) *PlayerQuery {
+	q.sortWeek = week
+	return q
+}
+
+// Start skips the given number of matching players.
+func (q *PlayerQuery) Start(start int) *PlayerQuery {
+	q.start = start
+	return q
+}
+
+// Count limits the number of players returned. All matching players are
+// returned when the count is not positive.
+func (q *PlayerQuery) Count(count int) *PlayerQuery {
+	q.count = count
+	return q
+}
+
+// params returns the query as parameters of the players collection for a
+// page of players.
+func (q *PlayerQuery) params(start int, count int) string {
+	params := ""
+	if q.status != "" {
+		params += ";status=" + q.status
+	}
+	if q.position != "" {
+		params += ";position=" + neturl.PathEscape(q.position)
+	}
+	if q.search != "" {
+		params += ";search=" + neturl.PathEscape(q.search)
+	}
+	if q.sort != "" {
+		params += ";sort=" + q.sort
+	}
+	if q.sortType != "" {
+		params += ";sort_type=" + q.sortType
+	}
+	if q.sortSeason != "" {
+		params += ";sort_season=" + q.sortSeason
+	}
+	if q.sortWeek > 0 {
+		params += fmt.Sprintf(";sort_week=%d", q.sortWeek)
+	}
+	return params + fmt.Sprintf(";start=%d;count=%d", start, count)
+}
+
+// SearchPlayers returns all players in the given league that match the
+// query. Yahoo limits the number of players returned by a single request, so
+// multiple requests are made when needed.
+//
+// See PlayerIterator to process players one page at a time.
+func (c *Client) SearchPlayers(leagueKey string, query *PlayerQuery) ([]Player, error) {
+	return c.SearchPlayersContext(context.Background(), leagueKey, query)
+}
+
+// SearchPlayersContext returns all players in the given league that match the
+// query using the given context for the API requests.
+func (c *Client) SearchPlayersContext(ctx context.Context, leagueKey string, query *PlayerQuery) ([]Player, error) {
+	iterator := c.IteratePlayersContext(ctx, leagueKey, query)
+	players := []Player{}
+	for iterator.Next() {
+		players = append(players, iterator.Player())
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// IteratePlayers returns an iterator over the players in the given league
+// that match the query. Pages of players are requested as they are needed.
+func (c *Client) IteratePlayers(leagueKey string, query *PlayerQuery) *PlayerIterator {
+	return c.IteratePlayersContext(context.Background(), leagueKey, query)
+}
+
+// IteratePlayersContext returns an iterator over the players in the given
+// league that match the query. Pages of players are requested as they are
+// needed using the given context.
+func (c *Client) IteratePlayersContext(ctx context.Context, leagueKey string, query *PlayerQuery) *PlayerIterator {
+	if query == nil {
+		query = NewPlayerQuery()
+	}
+	remaining := -1
+	if query.count > 0 {
+		remaining = query.count
+	}
+	return &PlayerIterator{
+		client:    c,
+		ctx:       ctx,
+		leagueKey: leagueKey,
+		query:     *query,
+		start:     query.start,
+		remaining: remaining,
+	}
+}
+
+// PlayerIterator steps through the players matching a PlayerQuery, for
+// example:
+//
+//    iterator := client.IteratePlayers(leagueKey, query)
+//    for iterator.Next() {
+//        player := iterator.Player()
+//        ...
+//    }
+//    if err := iterator.Err(); err != nil {
+//        ...
+//    }
+//
+// A PlayerIterator is not safe for concurrent use.
+type PlayerIterator struct {
+	client    *Client
+	ctx       context.Context
+	leagueKey string
+	query     PlayerQuery
+	// Position of the next page in the matching players
+	start int
+	// Number of players left to request, or -1 for no limit
+	remaining int
+	page      []Player
+	index     int
+	player    Player
+	done      bool
+	err       error
+}
+
+// Next advances to the next matching player, requesting the next page of
+// players when needed. It returns false once there are no more players or a
+// request fails.
+func (i *PlayerIterator) Next() bool {
+	for i.index >= len(i.page) {
+		if i.done || i.err != nil {
+			return false
+		}
+		i.nextPage()
+	}
+	i.player = i.page[i.index]
+	i.index++
+	return true
+}
+
+// Player returns the current player.
+func (i *PlayerIterator) Player() Player {
+	return i.player
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (i *PlayerIterator) Err() error {
+	return i.err
+}
+
+// nextPage requests the next page of players.
+func (i *PlayerIterator) nextPage() {
+	count := maxPlayersPerPage
+	if i.remaining >= 0 && i.remaining < count {
+		count = i.remaining
+	}
+	if count == 0 {
+		i.done = true
+		return
+	}
+
+	content, err := i.client.GetFantasyContentContext(
+		i.ctx,
+		fmt.Sprintf("%s/league/%s/players%s",
+			i.client.baseURL(),
+			i.leagueKey,
+			i.query.params(i.start, count)))
+	if err != nil {
+		i.err = err
+		return
+	}
+
+	players := content.League.Players
+	if len(players) > count {
+		players = players[:count]
+	}
+	i.page = players
+	i.index = 0
+	i.start += len(players)
+	if i.remaining >= 0 {
+		i.remaining -= len(players)
+	}
+	if len(players) < count {
+		i.done = true
+	}
+}
@@ -0,0 +1,229 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+//
+// Test PlayerQuery
+//
+
+func TestPlayerQueryParams(t *testing.T) {
+	query := NewPlayerQuery().
+		Status(FreeAgentPlayers).
+		Position("QB").
+		Search("van buren").
+		Sort(SortByPoints).
+		SortType(SortTypeWeek).
+		SortWeek(3)
+
+	assertStringEquals(
+		t,
+		";status=FA;position=QB;search=van%20buren;sort=PTS;sort_type=week;"+
+			"sort_week=3;start=25;count=10",
+		query.params(25, 10))
+}
+
+func TestPlayerQueryParamsEmpty(t *testing.T) {
+	assertStringEquals(
+		t,
+		";start=0;count=25",
+		NewPlayerQuery().params(0, 25))
+}
+
+//
+// Test SearchPlayers
+//
+
+func TestSearchPlayersAllPages(t *testing.T) {
+	provider := &mockedPagedContentProvider{total: 60}
+	client := &Client{Provider: provider}
+
+	query := NewPlayerQuery().Status(AvailablePlayers).Sort(SortByActualRank)
+	players, err := client.SearchPlayers("223.l.431", query)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertPlayerRange(t, 0, 60, players)
+	assertIntEquals(t, 3, len(provider.urls))
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/223.l.431/players;status=A;sort=AR;start=50;count=25",
+		provider.urls[2])
+}
+
+func TestSearchPlayersExactPage(t *testing.T) {
+	provider := &mockedPagedContentProvider{total: 50}
+	client := &Client{Provider: provider}
+
+	players, err := client.SearchPlayers("223.l.431", NewPlayerQuery())
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertPlayerRange(t, 0, 50, players)
+	assertIntEquals(t, 3, len(provider.urls))
+}
+
+func TestSearchPlayersStartAndCount(t *testing.T) {
+	provider := &mockedPagedContentProvider{total: 100}
+	client := &Client{Provider: provider}
+
+	players, err := client.SearchPlayers(
+		"223.l.431",
+		NewPlayerQuery().Start(10).Count(30))
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertPlayerRange(t, 10, 30, players)
+	assertIntEquals(t, 2, len(provider.urls))
+	assertURLContainsParam(t, provider.urls[0], "start", "10")
+	assertURLContainsParam(t, provider.urls[0], "count", "25")
+	assertURLContainsParam(t, provider.urls[1], "start", "35")
+	assertURLContainsParam(t, provider.urls[1], "count", "5")
+}
+
+func TestSearchPlayersNoResults(t *testing.T) {
+	provider := &mockedPagedContentProvider{total: 0}
+	client := &Client{Provider: provider}
+
+	players, err := client.SearchPlayers("223.l.431", nil)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	if players == nil || len(players) != 0 {
+		t.Fatalf("Unexpected players returned: %+v", players)
+	}
+	assertIntEquals(t, 1, len(provider.urls))
+}
+
+func TestSearchPlayersError(t *testing.T) {
+	expected := errors.New("error")
+	provider := &mockedPagedContentProvider{total: 60, failAfter: 1, err: expected}
+	client := &Client{Provider: provider}
+
+	players, err := client.SearchPlayers("223.l.431", NewPlayerQuery())
+	if err != expected {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			expected,
+			err)
+	}
+	if players != nil {
+		t.Fatalf("Unexpected players returned: %+v", players)
+	}
+}
+
+func TestIteratePlayers(t *testing.T) {
+	provider := &mockedPagedContentProvider{total: 30}
+	client := &Client{Provider: provider}
+
+	iterator := client.IteratePlayers("223.l.431", nil)
+	count := 0
+	for iterator.Next() {
+		player := iterator.Player()
+		assertStringEquals(t, playerKey(count), player.PlayerKey)
+		count++
+
+		// Pages are only requested as they are needed
+		if count == 25 {
+			assertIntEquals(t, 1, len(provider.urls))
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatalf("Iterator returned unexpected error: %s", err)
+	}
+
+	assertIntEquals(t, 30, count)
+	assertIntEquals(t, 2, len(provider.urls))
+	if iterator.Next() {
+		t.Fatal("Iterator continued after last player")
+	}
+}
+
+func TestIteratePlayersError(t *testing.T) {
+	expected := errors.New("error")
+	provider := &mockedPagedContentProvider{total: 60, failAfter: 1, err: expected}
+	client := &Client{Provider: provider}
+
+	iterator := client.IteratePlayersContext(context.Background(), "223.l.431", nil)
+	count := 0
+	for iterator.Next() {
+		count++
+	}
+
+	assertIntEquals(t, 25, count)
+	if iterator.Err() != expected {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			expected,
+			iterator.Err())
+	}
+}
+
+func assertPlayerRange(t *testing.T, start int, count int, players []Player) {
+	if len(players) != count {
+		t.Fatalf("Unexpected number of players\n\texpected: %d\n\tactual: %d",
+			count,
+			len(players))
+	}
+	for i, player := range players {
+		assertStringEquals(t, playerKey(start+i), player.PlayerKey)
+	}
+}
+
+func playerKey(index int) string {
+	return fmt.Sprintf("223.p.%d", index)
+}
+
+var pageParams = regexp.MustCompile(`;start=(\d+);count=(\d+)`)
+
+// mockedPagedContentProvider returns the requested page of a league's
+// players out of the given total number of players.
+type mockedPagedContentProvider struct {
+	total     int
+	failAfter int
+	err       error
+	urls      []string
+}
+
+func (m *mockedPagedContentProvider) Get(ctx context.Context, url string) (*FantasyContent, error) {
+	m.urls = append(m.urls, url)
+	if m.err != nil && len(m.urls) > m.failAfter {
+		return nil, m.err
+	}
+
+	match := pageParams.FindStringSubmatch(url)
+	if match == nil {
+		return nil, fmt.Errorf("no page requested: %s", url)
+	}
+	start, _ := strconv.Atoi(match[1])
+	count, _ := strconv.Atoi(match[2])
+	if count > maxPlayersPerPage {
+		return nil, fmt.Errorf("too many players requested: %s", url)
+	}
+
+	players := []Player{}
+	for i := start; i < start+count && i < m.total; i++ {
+		players = append(players, Player{PlayerKey: playerKey(i)})
+	}
+	return &FantasyContent{League: League{Players: players}}, nil
+}
+
+func (m *mockedPagedContentProvider) Send(ctx context.Context, method string, url string, body []byte) (*FantasyContent, error) {
+	return m.Get(ctx, url)
+}
+
+func (m *mockedPagedContentProvider) RequestCount() int {
+	return len(m.urls)
+}
+
+func (m *mockedPagedContentProvider) RequestCountByResource() map[string]int {
+	return map[string]int{"league": len(m.urls)}
+}
@@ -0,0 +1,120 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+//
+// Rate Limiting
+//
+
+// ErrRateLimitExceeded is returned instead of making a request when a
+// RateLimiter configured to fail fast has no requests available.
+var ErrRateLimitExceeded = errors.New("client rate limit exceeded")
+
+// RateLimiter limits the rate of requests made to the fantasy sports API
+// using a token bucket. It is safe for concurrent use and the same limiter can
+// be given to multiple clients, for example all clients sharing one
+// application key, to keep their combined requests under a single budget.
+//
+// See NewRateLimiter and WithRateLimiter
+type RateLimiter struct {
+	// Whether requests should fail with ErrRateLimitExceeded instead of
+	// waiting when the limit has been reached. This should be set before the
+	// limiter is used.
+	FailFast bool
+
+	mutex    sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	delayed  int
+	rejected int
+	now      func() time.Time
+}
+
+// NewRateLimiter creates a RateLimiter that allows the given number of
+// requests over each period, for example 10000 requests per time.Hour. Up to
+// burst requests can be made at once before requests start being delayed.
+func NewRateLimiter(requests int, per time.Duration, burst int) *RateLimiter {
+	if requests < 1 {
+		requests = 1
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: per / time.Duration(requests),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// Wait blocks until a request can be made or the given context is done. If
+// the limiter is set to FailFast, ErrRateLimitExceeded is returned instead of
+// waiting.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mutex.Lock()
+	l.refill()
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mutex.Unlock()
+		return nil
+	}
+
+	if l.FailFast {
+		l.rejected++
+		l.mutex.Unlock()
+		return ErrRateLimitExceeded
+	}
+
+	// Reserve the next available request and wait until it is ready
+	l.tokens--
+	l.delayed++
+	delay := time.Duration(-l.tokens * float64(l.interval))
+	l.mutex.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+// DelayedCount returns the amount of requests that had to wait before being
+// allowed by this limiter.
+func (l *RateLimiter) DelayedCount() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.delayed
+}
+
+// RejectedCount returns the amount of requests that failed with
+// ErrRateLimitExceeded.
+func (l *RateLimiter) RejectedCount() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.rejected
+}
+
+// refill adds the requests that became available since the last refill. The
+// limiter's mutex must be held.
+func (l *RateLimiter) refill() {
+	now := l.now()
+	if !l.last.IsZero() && l.interval > 0 {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	} else if l.interval <= 0 {
+		l.tokens = l.burst
+	}
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
@@ -0,0 +1,169 @@
+package goff
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+//
+// Test RateLimiter
+//
+
+func TestNewRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(3600, time.Hour, 10)
+
+	if limiter.interval != time.Second {
+		t.Fatalf("Unexpected interval\n\texpected: %s\n\tactual: %s",
+			time.Second,
+			limiter.interval)
+	}
+	assertFloatEquals(t, 10, limiter.tokens)
+	assertBoolEquals(t, false, limiter.FailFast)
+}
+
+func TestRateLimiterAllowsBurst(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour, 3)
+	limiter.FailFast = true
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Request %d within burst not allowed: %s", i, err)
+		}
+	}
+
+	if err := limiter.Wait(context.Background()); err != ErrRateLimitExceeded {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			ErrRateLimitExceeded,
+			err)
+	}
+	assertIntEquals(t, 1, limiter.RejectedCount())
+	assertIntEquals(t, 0, limiter.DelayedCount())
+}
+
+func TestRateLimiterRefills(t *testing.T) {
+	now := time.Unix(1408281677, 0)
+	limiter := NewRateLimiter(60, time.Minute, 1)
+	limiter.FailFast = true
+	limiter.now = func() time.Time { return now }
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := limiter.Wait(context.Background()); err == nil {
+		t.Fatal("Request allowed before limiter refilled")
+	}
+
+	now = now.Add(time.Second)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Request not allowed after limiter refilled: %s", err)
+	}
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		limiter.Wait(context.Background())
+	}
+	assertIntEquals(t, 2, limiter.RejectedCount())
+}
+
+func TestRateLimiterDelays(t *testing.T) {
+	limiter := NewRateLimiter(1, 10*time.Millisecond, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("Requests were not delayed, elapsed: %s", elapsed)
+	}
+	assertIntEquals(t, 2, limiter.DelayedCount())
+	assertIntEquals(t, 0, limiter.RejectedCount())
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			context.DeadlineExceeded,
+			err)
+	}
+
+	if limiter.tokens < -0.01 {
+		t.Fatalf("Canceled request not returned to limiter, tokens: %f",
+			limiter.tokens)
+	}
+}
+
+func TestRateLimiterConcurrentUse(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour, 10)
+	limiter.FailFast = true
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter.Wait(context.Background()) == nil {
+				mutex.Lock()
+				allowed++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assertIntEquals(t, 10, allowed)
+	assertIntEquals(t, 40, limiter.RejectedCount())
+}
+
+//
+// Test countingHTTPApiClient rate limiting
+//
+
+func TestCountingHTTPClientRateLimited(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour, 1)
+	limiter.FailFast = true
+	httpClient := &mockHTTPClient{Response: &http.Response{}}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		rateLimiter: limiter,
+	}
+
+	if _, err := client.Get(context.Background(), "http://example.com"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if _, err := client.Get(context.Background(), "http://example.com"); err != ErrRateLimitExceeded {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			ErrRateLimitExceeded,
+			err)
+	}
+
+	assertIntEquals(t, 1, client.RequestCount())
+	assertIntEquals(t, 1, httpClient.RequestCount)
+}
+
+func TestNewCachedClientWithRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour, 1)
+	client := NewCachedClient(mockCache(), &mockHTTPClient{}, WithRateLimiter(limiter))
+
+	delegate := client.Provider.(*cachedContentProvider).delegate
+	httpClient := delegate.(*xmlContentProvider).client.(*countingHTTPApiClient)
+	if httpClient.rateLimiter != limiter {
+		t.Fatalf("Rate limiter not set on client\n\texpected: %+v\n\t"+
+			"actual: %+v",
+			limiter,
+			httpClient.rateLimiter)
+	}
+}
@@ -0,0 +1,150 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+//
+// Retry Policy
+//
+
+// RetryPolicy determines when and how often failed requests to the fantasy
+// sports API are retried. Each attempt counts towards the client's
+// RequestCount.
+type RetryPolicy struct {
+	// Maximum number of attempts made for a single request, including the
+	// first. Values less than 1 are treated as 1, meaning no retries.
+	MaxAttempts int
+
+	// Delay before the first retry. The delay doubles after each subsequent
+	// attempt.
+	BaseDelay time.Duration
+
+	// Maximum delay between two attempts, or zero for no maximum. A
+	// Retry-After duration sent by Yahoo takes precedence over this value.
+	MaxDelay time.Duration
+
+	// Fraction of each delay, from 0 to 1, that is randomized to avoid many
+	// clients retrying at the same moment.
+	Jitter float64
+
+	// Reports whether a request that failed with the given error should be
+	// retried. IsRetryable is used when this is nil.
+	ShouldRetry func(err error) bool
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by clients that are not
+// given one using WithRetryPolicy. It retries transient failures up to four
+// times using exponential backoff starting at half a second.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.5,
+		ShouldRetry: IsRetryable,
+	}
+}
+
+// IsRetryable reports whether a request that failed with the given error may
+// succeed if tried again. This is true for an APIError marked Retryable,
+// timeouts, dropped connections, and the "consumer_key_unknown" error Yahoo
+// intermittently returns for valid consumer keys.
+//
+// See https://developer.yahoo.com/forum/OAuth-General-Discussion-YDN-SDKs/oauth-problem-consumer-key-unknown-/1375188859720-5cea9bdb-0642-4606-9fd5-c5f369112959
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// Usually the timeout of a single attempt set using WithTimeout. The
+	// caller's own context is checked before each retry.
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		strings.Contains(err.Error(), "consumer_key_unknown")
+}
+
+// attempts returns the maximum number of attempts allowed by the policy.
+func (p *RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry returns whether a request that failed with the given error
+// should be attempted again.
+func (p *RetryPolicy) shouldRetry(err error) bool {
+	if p.ShouldRetry == nil {
+		return IsRetryable(err)
+	}
+	return p.ShouldRetry(err)
+}
+
+// delay returns how long to wait before the next attempt after the given
+// attempt, starting at 1, failed with the given error.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+	return delay
+}
+
+// sleepContext pauses for the given duration or until the context is done,
+// in which case the context's error is returned.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,366 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+//
+// Test RetryPolicy
+//
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	if policy.MaxAttempts != 5 {
+		t.Fatalf("Unexpected default max attempts\n\texpected: 5\n\t"+
+			"actual: %d",
+			policy.MaxAttempts)
+	}
+
+	if policy.ShouldRetry == nil {
+		t.Fatal("No retry predicate in default policy")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := map[error]bool{
+		nil:                                false,
+		errors.New("error"):                false,
+		errors.New("consumer_key_unknown"): true,
+		context.Canceled:                   false,
+		context.DeadlineExceeded:           true,
+		io.ErrUnexpectedEOF:                true,
+		&APIError{StatusCode: 404}:         false,
+		&APIError{StatusCode: 999, Retryable: true}:           true,
+		fmt.Errorf("wrapped: %w", &APIError{Retryable: true}): true,
+		mockTimeoutError{}: true,
+	}
+
+	for err, expected := range tests {
+		if actual := IsRetryable(err); actual != expected {
+			t.Fatalf("Unexpected result for error: %v\n\texpected: %t\n\t"+
+				"actual: %t",
+				err,
+				expected,
+				actual)
+		}
+	}
+}
+
+func TestRetryPolicyAttempts(t *testing.T) {
+	assertIntEquals(t, 1, (&RetryPolicy{}).attempts())
+	assertIntEquals(t, 1, (&RetryPolicy{MaxAttempts: -1}).attempts())
+	assertIntEquals(t, 3, (&RetryPolicy{MaxAttempts: 3}).attempts())
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: time.Second,
+		MaxDelay:  5 * time.Second,
+	}
+	err := errors.New("error")
+
+	expected := []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		5 * time.Second,
+		5 * time.Second,
+	}
+	for i, delay := range expected {
+		actual := policy.delay(i+1, err)
+		if actual != delay {
+			t.Fatalf("Unexpected delay for attempt %d\n\texpected: %s\n\t"+
+				"actual: %s",
+				i+1,
+				delay,
+				actual)
+		}
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: time.Second,
+		Jitter:    0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		delay := policy.delay(1, errors.New("error"))
+		if delay < 500*time.Millisecond || delay > time.Second {
+			t.Fatalf("Delay outside of jitter range: %s", delay)
+		}
+	}
+}
+
+func TestRetryPolicyDelayRetryAfter(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: time.Second,
+		MaxDelay:  time.Second,
+	}
+	err := &APIError{StatusCode: 999, RetryAfter: 10 * time.Second}
+
+	if delay := policy.delay(1, err); delay != err.RetryAfter {
+		t.Fatalf("Retry-After not honored\n\texpected: %s\n\tactual: %s",
+			err.RetryAfter,
+			delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"-1":                            0,
+		"invalid":                       0,
+		"Thu, 01 Sep 2022 12:00:30 GMT": 30 * time.Second,
+		"Thu, 01 Sep 2022 11:00:00 GMT": 0,
+	}
+
+	for value, expected := range tests {
+		if actual := parseRetryAfter(value, now); actual != expected {
+			t.Fatalf("Unexpected duration for Retry-After: %s\n\t"+
+				"expected: %s\n\tactual: %s",
+				value,
+				expected,
+				actual)
+		}
+	}
+}
+
+func TestSleepContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, time.Hour); err != context.Canceled {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			context.Canceled,
+			err)
+	}
+}
+
+//
+// Test countingHTTPApiClient retries
+//
+
+func TestCountingHTTPClientRetriesServerErrors(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{
+			http.StatusServiceUnavailable,
+			StatusRateLimited,
+			http.StatusOK,
+		},
+	}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		retryPolicy: &RetryPolicy{MaxAttempts: 3},
+	}
+
+	response, err := client.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	assertIntEquals(t, http.StatusOK, response.StatusCode)
+	assertIntEquals(t, 3, client.RequestCount())
+}
+
+func TestCountingHTTPClientRetriesExhausted(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{
+			http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable,
+			http.StatusOK,
+		},
+	}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		retryPolicy: &RetryPolicy{MaxAttempts: 2},
+	}
+
+	_, err := client.Get(context.Background(), "http://example.com")
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			ErrServerError,
+			err)
+	}
+
+	assertIntEquals(t, 2, client.RequestCount())
+}
+
+func TestCountingHTTPClientSendDoesNotRetryServerErrors(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+	}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		retryPolicy: &RetryPolicy{MaxAttempts: 3},
+	}
+
+	_, err := client.Send(
+		context.Background(),
+		http.MethodPost,
+		"http://example.com",
+		[]byte("<fantasy_content/>"))
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			ErrServerError,
+			err)
+	}
+
+	assertIntEquals(t, 1, client.RequestCount())
+}
+
+func TestCountingHTTPClientSendRetriesRateLimited(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{StatusRateLimited, http.StatusOK},
+	}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		retryPolicy: &RetryPolicy{MaxAttempts: 3},
+	}
+
+	response, err := client.Send(
+		context.Background(),
+		http.MethodPut,
+		"http://example.com",
+		[]byte("<fantasy_content/>"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	assertIntEquals(t, http.StatusOK, response.StatusCode)
+	assertIntEquals(t, 2, client.RequestCount())
+}
+
+func TestCountingHTTPClientDoesNotRetryPermanentErrors(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{http.StatusNotFound, http.StatusOK},
+	}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		retryPolicy: &RetryPolicy{MaxAttempts: 5},
+	}
+
+	_, err := client.Get(context.Background(), "http://example.com")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			ErrNotFound,
+			err)
+	}
+
+	assertIntEquals(t, 1, client.RequestCount())
+}
+
+func TestCountingHTTPClientRetriesConsumerKeyUnknown(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{http.StatusUnauthorized, http.StatusOK},
+		body:     consumerKeyUnknownXMLContent,
+	}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		retryPolicy: &RetryPolicy{MaxAttempts: 3},
+	}
+
+	response, err := client.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	assertIntEquals(t, http.StatusOK, response.StatusCode)
+	assertIntEquals(t, 2, client.RequestCount())
+}
+
+func TestCountingHTTPClientCustomShouldRetry(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{http.StatusNotFound, http.StatusOK},
+	}
+	var retriedErr error
+	client := &countingHTTPApiClient{
+		client: httpClient,
+		retryPolicy: &RetryPolicy{
+			MaxAttempts: 5,
+			ShouldRetry: func(err error) bool {
+				retriedErr = err
+				return true
+			},
+		},
+	}
+
+	_, err := client.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !errors.Is(retriedErr, ErrNotFound) {
+		t.Fatalf("Typed error not given to retry predicate: %v", retriedErr)
+	}
+
+	assertIntEquals(t, 2, client.RequestCount())
+}
+
+func TestCountingHTTPClientRetryCanceled(t *testing.T) {
+	httpClient := &mockStatusHTTPClient{
+		statuses: []int{StatusRateLimited, http.StatusOK},
+	}
+	client := &countingHTTPApiClient{
+		client:      httpClient,
+		retryPolicy: &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err := client.Get(ctx, "http://example.com")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Unexpected error\n\texpected: %s\n\tactual: %v",
+			context.DeadlineExceeded,
+			err)
+	}
+
+	assertIntEquals(t, 1, client.RequestCount())
+}
+
+func TestNewClientWithRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 2}
+	client := NewClient(&mockHTTPClient{}, WithRetryPolicy(policy))
+
+	httpClient := client.Provider.(*xmlContentProvider).client.(*countingHTTPApiClient)
+	assertIntEquals(t, policy.MaxAttempts, httpClient.retryPolicy.MaxAttempts)
+}
+
+func TestNewClientDefaultRetryPolicy(t *testing.T) {
+	client := NewClient(&mockHTTPClient{})
+
+	httpClient := client.Provider.(*xmlContentProvider).client.(*countingHTTPApiClient)
+	assertIntEquals(
+		t,
+		DefaultRetryPolicy().MaxAttempts,
+		httpClient.retryPolicy.MaxAttempts)
+}
+
+// mockStatusHTTPClient responds to each request with the next status code
+type mockStatusHTTPClient struct {
+	statuses []int
+	// Body of every response
+	body     string
+	requests int
+}
+
+func (m *mockStatusHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	status := m.statuses[m.requests]
+	m.requests++
+	response := mockResponse(m.body)
+	response.StatusCode = status
+	return response, nil
+}
+
+type mockTimeoutError struct{}
+
+func (mockTimeoutError) Error() string   { return "timeout" }
+func (mockTimeoutError) Timeout() bool   { return true }
+func (mockTimeoutError) Temporary() bool { return true }
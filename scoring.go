@@ -0,0 +1,145 @@
+package goff
+
+import (
+	"math"
+	"strconv"
+)
+
+//
+// Scoring
+//
+
+// benchPositions are the positions that do not earn points for a team when
+// a league's settings do not describe its roster positions.
+var benchPositions = map[string]bool{
+	"BN":  true,
+	"IR":  true,
+	"IR+": true,
+	"IL":  true,
+	"IL+": true,
+	"NA":  true,
+}
+
+// A Scorer computes fantasy points from raw stats using the stat modifiers
+// of a league's Settings. It can be used to check the points reported by
+// Yahoo or to see how players would score under different settings, for
+// example by changing the modifier for receptions.
+type Scorer struct {
+	modifiers         map[int]StatModifier
+	startingPositions map[string]bool
+}
+
+// NewScorer creates a Scorer for a league with the given settings. Changes
+// made to the settings afterwards do not affect the Scorer.
+func NewScorer(settings Settings) *Scorer {
+	modifiers := make(map[int]StatModifier, len(settings.StatModifiers))
+	for _, modifier := range settings.StatModifiers {
+		modifiers[modifier.StatID] = modifier
+	}
+
+	// Without any starting positions, fall back to benchPositions
+	var startingPositions map[string]bool
+	for _, position := range settings.RosterPositions {
+		if position.IsStartingPosition {
+			if startingPositions == nil {
+				startingPositions = make(map[string]bool, len(settings.RosterPositions))
+			}
+			startingPositions[position.Position] = true
+		}
+	}
+
+	return &Scorer{
+		modifiers:         modifiers,
+		startingPositions: startingPositions,
+	}
+}
+
+// Score returns the fantasy points earned for the given stats. Each stat
+// earns its value multiplied by the modifier for the stat, which may be
+// negative, plus the points for every bonus whose target the value reaches.
+// Stats without a modifier or a numeric value earn no points. Like Yahoo, the
+// result is rounded to two decimal places.
+func (s *Scorer) Score(stats []Stat) float64 {
+	total := 0.0
+	for _, stat := range stats {
+		modifier, ok := s.modifiers[stat.StatID]
+		if !ok {
+			continue
+		}
+		value, err := stat.Float()
+		if err != nil {
+			continue
+		}
+
+		total += value * modifier.Value
+		for _, bonus := range modifier.Bonuses {
+			if value >= bonus.Target {
+				total += bonus.Points
+			}
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+// PlayerPoints returns the fantasy points earned by the player's Stats.
+func (s *Scorer) PlayerPoints(player Player) float64 {
+	return s.Score(player.Stats)
+}
+
+// RosterPoints returns the fantasy points earned by the players in a
+// starting position on the roster. Players on the bench or injured reserve
+// do not count towards the total.
+func (s *Scorer) RosterPoints(roster Roster) float64 {
+	total := 0.0
+	for _, player := range roster.Players {
+		if s.isStarting(player.SelectedPosition.Position) {
+			total += s.PlayerPoints(player)
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+// RescoreRoster returns a copy of the roster with the points of each player
+// replaced by the points computed from their stats.
+func (s *Scorer) RescoreRoster(roster Roster) Roster {
+	players := make([]Player, len(roster.Players))
+	for i, player := range roster.Players {
+		player.PlayerPoints = rescorePoints(player.PlayerPoints, s.PlayerPoints(player))
+		players[i] = player
+	}
+	roster.Players = players
+	return roster
+}
+
+// RescoreMatchup returns a copy of the matchup with the rosters and points of
+// each team replaced by the points computed from the stats of their players.
+// Teams without roster players, such as those in matchups from
+// GetMatchupsForWeekRange, keep the points reported by Yahoo.
+func (s *Scorer) RescoreMatchup(matchup Matchup) Matchup {
+	teams := make([]Team, len(matchup.Teams))
+	for i, team := range matchup.Teams {
+		if len(team.Roster.Players) > 0 {
+			team.Roster = s.RescoreRoster(team.Roster)
+			team.TeamPoints = rescorePoints(team.TeamPoints, s.RosterPoints(team.Roster))
+		}
+		teams[i] = team
+	}
+	matchup.Teams = teams
+	return matchup
+}
+
+// isStarting returns whether players in the given position earn points for
+// their team.
+func (s *Scorer) isStarting(position string) bool {
+	if s.startingPositions != nil {
+		return s.startingPositions[position]
+	}
+	return position != "" && !benchPositions[position]
+}
+
+// rescorePoints returns a copy of the points with the given total.
+func rescorePoints(points Points, total float64) Points {
+	points.Total = total
+	points.TotalStr = strconv.FormatFloat(total, 'f', -1, 64)
+	return points
+}
@@ -0,0 +1,236 @@
+package goff
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+//
+// Test Scorer
+//
+
+func TestScorerScore(t *testing.T) {
+	scorer := NewScorer(scoringSettings)
+
+	stats := []Stat{
+		Stat{StatID: 4, Value: "312"}, // 312 passing yards
+		Stat{StatID: 5, Value: "2"},   // 2 passing touchdowns
+		Stat{StatID: 6, Value: "1"},   // 1 interception
+		Stat{StatID: 1, Value: "40"},  // attempts are not scored
+	}
+
+	// 12.48 + 3 bonus + 8 - 2
+	assertFloatEquals(t, 21.48, scorer.Score(stats))
+}
+
+func TestScorerScoreNegativeStats(t *testing.T) {
+	scorer := NewScorer(scoringSettings)
+
+	stats := []Stat{
+		Stat{StatID: 9, Value: "-12"}, // -12 rushing yards
+		Stat{StatID: 6, Value: "2"},   // 2 interceptions
+	}
+
+	assertFloatEquals(t, -5.2, scorer.Score(stats))
+}
+
+func TestScorerScoreMultipleBonuses(t *testing.T) {
+	scorer := NewScorer(scoringSettings)
+
+	stats := []Stat{Stat{StatID: 4, Value: "400"}}
+
+	// 16 + 3 + 5
+	assertFloatEquals(t, 24, scorer.Score(stats))
+}
+
+func TestScorerScoreSkipsMissingValues(t *testing.T) {
+	scorer := NewScorer(scoringSettings)
+
+	stats := []Stat{
+		Stat{StatID: 4, Value: "-"},
+		Stat{StatID: 5, Value: ""},
+		Stat{StatID: 11, Value: "5"},
+	}
+
+	assertFloatEquals(t, 0, scorer.Score(stats))
+}
+
+func TestScorerPlayerPointsPPR(t *testing.T) {
+	player := Player{
+		PlayerKey: "314.p.1",
+		Stats: []Stat{
+			Stat{StatID: 11, Value: "8"},  // 8 receptions
+			Stat{StatID: 12, Value: "95"}, // 95 receiving yards
+		},
+	}
+
+	standard := NewScorer(scoringSettings)
+	assertFloatEquals(t, 9.5, standard.PlayerPoints(player))
+
+	ppr := scoringSettings
+	ppr.StatModifiers = append(
+		[]StatModifier{StatModifier{StatID: 11, Value: 1}},
+		scoringSettings.StatModifiers...)
+	assertFloatEquals(t, 17.5, NewScorer(ppr).PlayerPoints(player))
+}
+
+func TestScorerRosterPoints(t *testing.T) {
+	scorer := NewScorer(scoringSettings)
+
+	assertFloatEquals(t, 21, scorer.RosterPoints(scoringRoster))
+}
+
+func TestScorerRosterPointsDefaultBench(t *testing.T) {
+	settings := scoringSettings
+	settings.RosterPositions = nil
+	scorer := NewScorer(settings)
+
+	assertFloatEquals(t, 21, scorer.RosterPoints(scoringRoster))
+}
+
+func TestScorerRosterPointsNoStartingPositions(t *testing.T) {
+	settings := scoringSettings
+	settings.RosterPositions = make([]RosterPosition, len(scoringSettings.RosterPositions))
+	for i, position := range scoringSettings.RosterPositions {
+		position.IsStartingPosition = false
+		settings.RosterPositions[i] = position
+	}
+	scorer := NewScorer(settings)
+
+	assertFloatEquals(t, 21, scorer.RosterPoints(scoringRoster))
+}
+
+func TestScorerRescoreRoster(t *testing.T) {
+	scorer := NewScorer(scoringSettings)
+
+	roster := scorer.RescoreRoster(scoringRoster)
+	assertFloatEquals(t, 16, roster.Players[0].PlayerPoints.Total)
+	assertStringEquals(t, "16", roster.Players[0].PlayerPoints.TotalStr)
+	assertFloatEquals(t, 5, roster.Players[1].PlayerPoints.Total)
+	assertFloatEquals(t, 12, roster.Players[2].PlayerPoints.Total)
+	assertStringEquals(t, "week", roster.Players[0].PlayerPoints.CoverageType)
+
+	// The original roster is unchanged
+	assertFloatEquals(t, 99, scoringRoster.Players[0].PlayerPoints.Total)
+}
+
+func TestScorerRescoreMatchup(t *testing.T) {
+	scorer := NewScorer(scoringSettings)
+	matchup := Matchup{
+		Week: 1,
+		Teams: []Team{
+			Team{TeamKey: "314.l.1.t.1", Roster: scoringRoster},
+			Team{
+				TeamKey:    "314.l.1.t.2",
+				TeamPoints: Points{Total: 87.5, TotalStr: "87.5"},
+			},
+		},
+	}
+
+	rescored := scorer.RescoreMatchup(matchup)
+	assertIntEquals(t, 1, rescored.Week)
+	assertStringEquals(t, "314.l.1.t.1", rescored.Teams[0].TeamKey)
+	assertFloatEquals(t, 21, rescored.Teams[0].TeamPoints.Total)
+	assertFloatEquals(t, 16, rescored.Teams[0].Roster.Players[0].PlayerPoints.Total)
+	// Teams without a roster keep their points
+	assertFloatEquals(t, 87.5, rescored.Teams[1].TeamPoints.Total)
+	assertStringEquals(t, "87.5", rescored.Teams[1].TeamPoints.TotalStr)
+	assertFloatEquals(t, 0, matchup.Teams[0].TeamPoints.Total)
+}
+
+func TestScorerIgnoresLaterSettingsChanges(t *testing.T) {
+	settings := Settings{
+		StatModifiers: []StatModifier{StatModifier{StatID: 5, Value: 4}},
+	}
+	scorer := NewScorer(settings)
+	settings.StatModifiers[0].Value = 6
+
+	assertFloatEquals(t, 4, scorer.Score([]Stat{Stat{StatID: 5, Value: "1"}}))
+}
+
+func TestUnmarshalStatModifierBonuses(t *testing.T) {
+	var settings Settings
+	err := xml.Unmarshal([]byte(statModifierBonusesXMLContent), &settings)
+	if err != nil {
+		t.Fatalf("unexpected error parsing settings: %s", err)
+	}
+
+	if len(settings.StatModifiers) != 1 {
+		t.Fatalf("Unexpected stat modifiers: %+v", settings.StatModifiers)
+	}
+	bonuses := settings.StatModifiers[0].Bonuses
+	if len(bonuses) != 2 {
+		t.Fatalf("Unexpected bonuses: %+v", bonuses)
+	}
+	assertFloatEquals(t, 300, bonuses[0].Target)
+	assertFloatEquals(t, 3, bonuses[0].Points)
+	assertFloatEquals(t, 400, bonuses[1].Target)
+	assertFloatEquals(t, 5, bonuses[1].Points)
+}
+
+var scoringSettings = Settings{
+	RosterPositions: []RosterPosition{
+		RosterPosition{Position: "QB", Count: 1, IsStartingPosition: true},
+		RosterPosition{Position: "WR", Count: 2, IsStartingPosition: true},
+		RosterPosition{Position: "BN", Count: 5, IsStartingPosition: false},
+	},
+	StatModifiers: []StatModifier{
+		StatModifier{
+			StatID: 4,
+			Value:  0.04,
+			Bonuses: []StatBonus{
+				StatBonus{Target: 300, Points: 3},
+				StatBonus{Target: 400, Points: 5},
+			},
+		},
+		StatModifier{StatID: 5, Value: 4},
+		StatModifier{StatID: 6, Value: -2},
+		StatModifier{StatID: 9, Value: 0.1},
+		StatModifier{StatID: 12, Value: 0.1},
+	},
+}
+
+var scoringRoster = Roster{
+	CoverageType: WeekCoverage,
+	Week:         1,
+	Players: []Player{
+		Player{
+			PlayerKey:        "314.p.1",
+			SelectedPosition: SelectedPosition{Position: "QB"},
+			PlayerPoints:     Points{CoverageType: "week", Total: 99},
+			Stats:            []Stat{Stat{StatID: 5, Value: "4"}},
+		},
+		Player{
+			PlayerKey:        "314.p.2",
+			SelectedPosition: SelectedPosition{Position: "WR"},
+			Stats:            []Stat{Stat{StatID: 12, Value: "50"}},
+		},
+		Player{
+			PlayerKey:        "314.p.3",
+			SelectedPosition: SelectedPosition{Position: "BN"},
+			Stats:            []Stat{Stat{StatID: 5, Value: "3"}},
+		},
+	},
+}
+
+var statModifierBonusesXMLContent = `
+<settings>
+  <stat_modifiers>
+    <stats>
+      <stat>
+        <stat_id>4</stat_id>
+        <value>0.04</value>
+        <bonuses>
+          <bonus>
+            <target>300</target>
+            <points>3</points>
+          </bonus>
+          <bonus>
+            <target>400</target>
+            <points>5</points>
+          </bonus>
+        </bonuses>
+      </stat>
+    </stats>
+  </stat_modifiers>
+</settings>`
@@ -0,0 +1,25 @@
+package goff
+
+//
+// League Settings
+//
+
+// StatModifierValues maps the ID of each stat to the number of points earned
+// for each unit of it in the league.
+func (s *Settings) StatModifierValues() map[int]float64 {
+	values := make(map[int]float64, len(s.StatModifiers))
+	for _, modifier := range s.StatModifiers {
+		values[modifier.StatID] = modifier.Value
+	}
+	return values
+}
+
+// PositionCounts maps each roster position in the league to the number of
+// players that can fill it.
+func (s *Settings) PositionCounts() map[string]int {
+	counts := make(map[string]int, len(s.RosterPositions))
+	for _, position := range s.RosterPositions {
+		counts[position.Position] += position.Count
+	}
+	return counts
+}
@@ -0,0 +1,211 @@
+package goff
+
+import (
+	"context"
+	"testing"
+)
+
+//
+// Test Settings
+//
+
+func TestXMLContentProviderGetSettings(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(settingsXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	settings := content.League.Settings
+	assertStringEquals(t, "live", settings.DraftType)
+	assertBoolEquals(t, true, settings.IsAuctionDraft)
+	assertStringEquals(t, "head", settings.ScoringType)
+	assertBoolEquals(t, true, settings.UsesPlayoff)
+	assertIntEquals(t, 14, settings.PlayoffStartWeek)
+	assertIntEquals(t, 4, settings.NumPlayoffTeams)
+	assertBoolEquals(t, true, settings.HasPlayoffConsolationGames)
+	assertIntEquals(t, 12, settings.MaxTeams)
+	assertStringEquals(t, "FR", settings.WaiverType)
+	assertStringEquals(t, "gametime", settings.WaiverRule)
+	assertIntEquals(t, 2, settings.WaiverTime)
+	assertBoolEquals(t, true, settings.UsesFaab)
+	assertIntEquals(t, 100, settings.FaabBudget)
+	assertStringEquals(t, "2013-11-15", settings.TradeEndDate)
+	assertStringEquals(t, "vote", settings.TradeRatifyType)
+	assertIntEquals(t, 2, settings.TradeRejectTime)
+	assertBoolEquals(t, true, settings.UsesKeepers)
+	assertIntEquals(t, 2, settings.NumKeepers)
+
+	if len(settings.RosterPositions) != 3 {
+		t.Fatalf("Unexpected roster positions: %+v", settings.RosterPositions)
+	}
+	position := settings.RosterPositions[2]
+	assertStringEquals(t, "BN", position.Position)
+	assertIntEquals(t, 6, position.Count)
+	assertBoolEquals(t, false, position.IsStartingPosition)
+	assertBoolEquals(t, true, settings.RosterPositions[0].IsStartingPosition)
+	assertStringEquals(t, "O", settings.RosterPositions[0].PositionType)
+
+	if len(settings.StatCategories) != 2 {
+		t.Fatalf("Unexpected stat categories: %+v", settings.StatCategories)
+	}
+	category := settings.StatCategories[0]
+	assertIntEquals(t, 4, category.StatID)
+	assertBoolEquals(t, true, category.Enabled)
+	assertStringEquals(t, "Passing Yards", category.Name)
+	assertStringEquals(t, "O", category.PositionType)
+	assertBoolEquals(t, true, settings.StatCategories[1].IsOnlyDisplayStat)
+
+	if len(settings.StatModifiers) != 2 {
+		t.Fatalf("Unexpected stat modifiers: %+v", settings.StatModifiers)
+	}
+	assertIntEquals(t, 4, settings.StatModifiers[0].StatID)
+	assertFloatEquals(t, 0.04, settings.StatModifiers[0].Value)
+
+	if len(settings.Divisions) != 2 {
+		t.Fatalf("Unexpected divisions: %+v", settings.Divisions)
+	}
+	assertIntEquals(t, 2, settings.Divisions[1].DivisionID)
+	assertStringEquals(t, "West", settings.Divisions[1].Name)
+}
+
+func TestSettingsStatModifierValues(t *testing.T) {
+	settings := Settings{
+		StatModifiers: []StatModifier{
+			StatModifier{StatID: 4, Value: 0.04},
+			StatModifier{StatID: 5, Value: 4},
+		},
+	}
+
+	values := settings.StatModifierValues()
+	assertIntEquals(t, 2, len(values))
+	assertFloatEquals(t, 0.04, values[4])
+	assertFloatEquals(t, 4, values[5])
+}
+
+func TestSettingsPositionCounts(t *testing.T) {
+	settings := Settings{
+		RosterPositions: []RosterPosition{
+			RosterPosition{Position: "QB", Count: 1},
+			RosterPosition{Position: "WR", Count: 3},
+			RosterPosition{Position: "WR", Count: 1},
+		},
+	}
+
+	counts := settings.PositionCounts()
+	assertIntEquals(t, 2, len(counts))
+	assertIntEquals(t, 1, counts["QB"])
+	assertIntEquals(t, 4, counts["WR"])
+}
+
+func TestGetLeagueMetadataIncludesSettings(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{League: expectedLeague},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	client.GetLeagueMetadata(expectedLeague.LeagueKey)
+	assertURLContainsParam(t, provider.lastGetURL, "out", "settings")
+}
+
+var settingsXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/314.l.431;out=settings" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="48.871040344238ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <league>
+    <league_key>314.l.431</league_key>
+    <league_id>431</league_id>
+    <name>League Name</name>
+    <settings>
+      <draft_type>live</draft_type>
+      <is_auction_draft>1</is_auction_draft>
+      <scoring_type>head</scoring_type>
+      <uses_playoff>1</uses_playoff>
+      <has_playoff_consolation_games>1</has_playoff_consolation_games>
+      <playoff_start_week>14</playoff_start_week>
+      <uses_playoff_reseeding>0</uses_playoff_reseeding>
+      <num_playoff_teams>4</num_playoff_teams>
+      <num_playoff_consolation_teams>4</num_playoff_consolation_teams>
+      <waiver_type>FR</waiver_type>
+      <waiver_rule>gametime</waiver_rule>
+      <uses_faab>1</uses_faab>
+      <faab_budget>100</faab_budget>
+      <post_draft_players>W</post_draft_players>
+      <max_teams>12</max_teams>
+      <waiver_time>2</waiver_time>
+      <trade_end_date>2013-11-15</trade_end_date>
+      <trade_ratify_type>vote</trade_ratify_type>
+      <trade_reject_time>2</trade_reject_time>
+      <player_pool>ALL</player_pool>
+      <cant_cut_list>yahoo</cant_cut_list>
+      <uses_keepers>1</uses_keepers>
+      <num_keepers>2</num_keepers>
+      <roster_positions>
+        <roster_position>
+          <position>QB</position>
+          <position_type>O</position_type>
+          <count>1</count>
+          <is_starting_position>1</is_starting_position>
+        </roster_position>
+        <roster_position>
+          <position>WR</position>
+          <position_type>O</position_type>
+          <count>3</count>
+          <is_starting_position>1</is_starting_position>
+        </roster_position>
+        <roster_position>
+          <position>BN</position>
+          <count>6</count>
+          <is_starting_position>0</is_starting_position>
+        </roster_position>
+      </roster_positions>
+      <stat_categories>
+        <stats>
+          <stat>
+            <stat_id>4</stat_id>
+            <enabled>1</enabled>
+            <name>Passing Yards</name>
+            <display_name>Pass Yds</display_name>
+            <sort_order>1</sort_order>
+            <position_type>O</position_type>
+          </stat>
+          <stat>
+            <stat_id>1</stat_id>
+            <enabled>1</enabled>
+            <name>Passing Attempts</name>
+            <display_name>Pass Att</display_name>
+            <sort_order>1</sort_order>
+            <position_type>O</position_type>
+            <is_only_display_stat>1</is_only_display_stat>
+          </stat>
+        </stats>
+      </stat_categories>
+      <stat_modifiers>
+        <stats>
+          <stat>
+            <stat_id>4</stat_id>
+            <value>0.04</value>
+          </stat>
+          <stat>
+            <stat_id>5</stat_id>
+            <value>4</value>
+          </stat>
+        </stats>
+      </stat_modifiers>
+      <divisions>
+        <division>
+          <division_id>1</division_id>
+          <name>East</name>
+        </division>
+        <division>
+          <division_id>2</division_id>
+          <name>West</name>
+        </division>
+      </divisions>
+    </settings>
+  </league>
+</fantasy_content>`
@@ -0,0 +1,130 @@
+package goff
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//
+// Stats
+//
+
+// Types of periods that player stats can be requested for
+const (
+	// StatsTypeSeason covers a full season, the current one unless a Season
+	// is given
+	StatsTypeSeason = "season"
+	// StatsTypeAverageSeason covers the average per game over a season
+	StatsTypeAverageSeason = "average_season"
+	// StatsTypeWeek covers a single week given by Week
+	StatsTypeWeek = "week"
+	// StatsTypeLastWeek covers the most recently completed week
+	StatsTypeLastWeek = "lastweek"
+	// StatsTypeLastMonth covers the last 30 days
+	StatsTypeLastMonth = "lastmonth"
+	// StatsTypeDate covers a single day given by Date
+	StatsTypeDate = "date"
+)
+
+// StatsPeriod is the period of time that player stats are requested for.
+type StatsPeriod struct {
+	// Type of period, such as StatsTypeSeason or StatsTypeWeek
+	Type string
+	// Season for StatsTypeSeason and StatsTypeAverageSeason periods, or the
+	// current season if empty
+	Season string
+	// Week for StatsTypeWeek periods
+	Week int
+	// Date for StatsTypeDate periods
+	Date time.Time
+}
+
+// params returns the parameters of the stats subresource for the period.
+func (p StatsPeriod) params() string {
+	params := "type=" + p.Type
+	switch p.Type {
+	case StatsTypeSeason, StatsTypeAverageSeason:
+		if p.Season != "" {
+			params += ";season=" + p.Season
+		}
+	case StatsTypeWeek:
+		params += fmt.Sprintf(";week=%d", p.Week)
+	case StatsTypeDate:
+		params += ";date=" + p.Date.Format(DateFormat)
+	}
+	return params
+}
+
+// Stat is the value a player or team recorded for a single stat category.
+type Stat struct {
+	StatID int    `xml:"stat_id"`
+	Value  string `xml:"value"`
+}
+
+// Float returns the value of the stat as a number. An error is returned for
+// values that are not numbers, such as "-" for stats that were not recorded.
+func (s Stat) Float() (float64, error) {
+	return strconv.ParseFloat(s.Value, 64)
+}
+
+// A StatCategory describes a stat that is tracked by a game or used by a
+// league to score players.
+type StatCategory struct {
+	StatID        int      `xml:"stat_id"`
+	Name          string   `xml:"name"`
+	DisplayName   string   `xml:"display_name"`
+	Abbreviation  string   `xml:"abbr"`
+	SortOrder     int      `xml:"sort_order"`
+	PositionTypes []string `xml:"position_types>position_type"`
+	// Whether the league uses the stat, only set for league settings
+	Enabled bool `xml:"enabled"`
+	// Type of positions the stat applies to, only set for league settings
+	PositionType string `xml:"position_type"`
+	// Whether the stat is shown but not used for scoring, only set for
+	// league settings
+	IsOnlyDisplayStat bool `xml:"is_only_display_stat"`
+}
+
+// StatCategoriesByID maps the given categories by their stat ID, so that the
+// ID of a Stat can be resolved to its name.
+func StatCategoriesByID(categories []StatCategory) map[int]StatCategory {
+	byID := make(map[int]StatCategory, len(categories))
+	for _, category := range categories {
+		byID[category.StatID] = category
+	}
+	return byID
+}
+
+// GetStatCategories returns the stats tracked by the game with the given key,
+// e.g. NflGameKey or a key returned by GetGameKey.
+func (c *Client) GetStatCategories(gameKey string) ([]StatCategory, error) {
+	return c.GetStatCategoriesContext(context.Background(), gameKey)
+}
+
+// GetStatCategoriesContext returns the stats tracked by the game with the
+// given key using the given context for the API request.
+func (c *Client) GetStatCategoriesContext(ctx context.Context, gameKey string) ([]StatCategory, error) {
+	content, err := c.GetFantasyContentContext(
+		ctx,
+		fmt.Sprintf("%s/game/%s/stat_categories",
+			c.baseURL(),
+			gameKey))
+	if err != nil {
+		return nil, err
+	}
+	return content.Game.StatCategories, nil
+}
+
+// GetPlayersStatsForPeriod returns a list of Players containing their stats
+// for the given period, for example the season or the last week.
+func (c *Client) GetPlayersStatsForPeriod(leagueKey string, period StatsPeriod, players []Player) ([]Player, error) {
+	return c.GetPlayersStatsForPeriodContext(context.Background(), leagueKey, period, players)
+}
+
+// GetPlayersStatsForPeriodContext returns a list of Players containing their
+// stats for the given period using the given context for the API requests.
+func (c *Client) GetPlayersStatsForPeriodContext(ctx context.Context, leagueKey string, period StatsPeriod, players []Player) ([]Player, error) {
+	return c.getPlayersStats(ctx, leagueKey, period, players)
+}
@@ -0,0 +1,286 @@
+package goff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+//
+// Test StatsPeriod
+//
+
+func TestStatsPeriodParams(t *testing.T) {
+	tests := map[string]StatsPeriod{
+		"type=season": StatsPeriod{Type: StatsTypeSeason},
+		"type=season;season=2013": StatsPeriod{
+			Type:   StatsTypeSeason,
+			Season: "2013",
+		},
+		"type=average_season;season=2013": StatsPeriod{
+			Type:   StatsTypeAverageSeason,
+			Season: "2013",
+		},
+		"type=week;week=4": StatsPeriod{Type: StatsTypeWeek, Week: 4},
+		"type=lastweek":    StatsPeriod{Type: StatsTypeLastWeek, Week: 4},
+		"type=lastmonth":   StatsPeriod{Type: StatsTypeLastMonth},
+		"type=date;date=2023-05-04": StatsPeriod{
+			Type: StatsTypeDate,
+			Date: time.Date(2023, time.May, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for expected, period := range tests {
+		assertStringEquals(t, expected, period.params())
+	}
+}
+
+//
+// Test Stat
+//
+
+func TestStatFloat(t *testing.T) {
+	value, err := Stat{StatID: 4, Value: "312.5"}.Float()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertFloatEquals(t, 312.5, value)
+
+	if _, err := (Stat{StatID: 4, Value: "-"}).Float(); err == nil {
+		t.Fatal("No error returned for stat without a value")
+	}
+}
+
+func TestStatCategoriesByID(t *testing.T) {
+	byID := StatCategoriesByID(expectedStatCategories)
+
+	assertIntEquals(t, len(expectedStatCategories), len(byID))
+	assertStringEquals(t, "Passing Yards", byID[4].Name)
+	assertStringEquals(t, "Rec", byID[11].DisplayName)
+}
+
+//
+// Test GetStatCategories
+//
+
+func TestGetStatCategories(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			Game: Game{StatCategories: expectedStatCategories},
+		},
+		err: nil,
+	}
+	client := &Client{Provider: provider}
+
+	categories, err := client.GetStatCategories("314")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertIntEquals(t, len(expectedStatCategories), len(categories))
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/game/314/stat_categories",
+		provider.lastGetURL)
+}
+
+func TestGetStatCategoriesError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetStatCategories("314")
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+func TestXMLContentProviderGetStatCategories(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(statCategoriesXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	categories := content.Game.StatCategories
+	if len(categories) != len(expectedStatCategories) {
+		t.Fatalf("Unexpected categories parsed: %+v", categories)
+	}
+	for i, expected := range expectedStatCategories {
+		actual := categories[i]
+		assertIntEquals(t, expected.StatID, actual.StatID)
+		assertStringEquals(t, expected.Name, actual.Name)
+		assertStringEquals(t, expected.DisplayName, actual.DisplayName)
+		assertIntEquals(t, expected.SortOrder, actual.SortOrder)
+		assertStringEquals(t, expected.PositionTypes[0], actual.PositionTypes[0])
+	}
+}
+
+//
+// Test GetPlayersStatsForPeriod
+//
+
+func TestGetPlayersStatsForPeriod(t *testing.T) {
+	players := []Player{Player{PlayerKey: "314.p.8261"}}
+	provider := &mockedContentProvider{
+		content: &FantasyContent{League: League{Players: players}},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	actual, err := client.GetPlayersStatsForPeriod(
+		"314.l.431",
+		StatsPeriod{Type: StatsTypeSeason, Season: "2013"},
+		players)
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertStringEquals(t, players[0].PlayerKey, actual[0].PlayerKey)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/314.l.431/players;player_keys=314.p.8261/"+
+			"stats;type=season;season=2013",
+		provider.lastGetURL)
+}
+
+func TestGetPlayersStatsForPeriodError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetPlayersStatsForPeriod(
+		"314.l.431",
+		StatsPeriod{Type: StatsTypeLastWeek},
+		[]Player{Player{PlayerKey: "314.p.8261"}})
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+func TestXMLContentProviderGetPlayerStats(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(playerStatsXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	players := content.League.Players
+	if len(players) != 1 {
+		t.Fatalf("Unexpected players parsed: %+v", players)
+	}
+
+	stats := players[0].Stats
+	if len(stats) != 3 {
+		t.Fatalf("Unexpected stats parsed: %+v", stats)
+	}
+	assertIntEquals(t, 4, stats[0].StatID)
+	assertStringEquals(t, "312", stats[0].Value)
+	assertIntEquals(t, 5, stats[1].StatID)
+	assertStringEquals(t, "2", stats[1].Value)
+	assertIntEquals(t, 11, stats[2].StatID)
+	assertStringEquals(t, "-", stats[2].Value)
+	assertFloatEquals(t, 20.48, players[0].PlayerPoints.Total)
+}
+
+var expectedStatCategories = []StatCategory{
+	StatCategory{
+		StatID:        4,
+		Name:          "Passing Yards",
+		DisplayName:   "Pass Yds",
+		SortOrder:     1,
+		PositionTypes: []string{"O"},
+	},
+	StatCategory{
+		StatID:        11,
+		Name:          "Receptions",
+		DisplayName:   "Rec",
+		SortOrder:     1,
+		PositionTypes: []string{"O"},
+	},
+}
+
+var statCategoriesXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/game/314/stat_categories" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="21.064043045044ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <game>
+    <game_key>314</game_key>
+    <game_id>314</game_id>
+    <name>Football</name>
+    <code>nfl</code>
+    <type>full</type>
+    <season>2013</season>
+    <stat_categories>
+      <stats>
+        <stat>
+          <stat_id>4</stat_id>
+          <name>Passing Yards</name>
+          <display_name>Pass Yds</display_name>
+          <sort_order>1</sort_order>
+          <position_types>
+            <position_type>O</position_type>
+          </position_types>
+        </stat>
+        <stat>
+          <stat_id>11</stat_id>
+          <name>Receptions</name>
+          <display_name>Rec</display_name>
+          <sort_order>1</sort_order>
+          <position_types>
+            <position_type>O</position_type>
+          </position_types>
+        </stat>
+      </stats>
+    </stat_categories>
+  </game>
+</fantasy_content>`
+
+var playerStatsXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/314.l.431/players;player_keys=314.p.8261/stats;type=week;week=1" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="61.728954315186ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <league>
+    <league_key>314.l.431</league_key>
+    <league_id>431</league_id>
+    <name>League Name</name>
+    <players count="1">
+      <player>
+        <player_key>314.p.8261</player_key>
+        <player_id>8261</player_id>
+        <name>
+          <full>Firstname Lastname</full>
+          <first>Firstname</first>
+          <last>Lastname</last>
+        </name>
+        <display_position>QB</display_position>
+        <player_stats>
+          <coverage_type>week</coverage_type>
+          <week>1</week>
+          <stats>
+            <stat>
+              <stat_id>4</stat_id>
+              <value>312</value>
+            </stat>
+            <stat>
+              <stat_id>5</stat_id>
+              <value>2</value>
+            </stat>
+            <stat>
+              <stat_id>11</stat_id>
+              <value>-</value>
+            </stat>
+          </stats>
+        </player_stats>
+        <player_points>
+          <coverage_type>week</coverage_type>
+          <week>1</week>
+          <total>20.48</total>
+        </player_points>
+      </player>
+    </players>
+  </league>
+</fantasy_content>`
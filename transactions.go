@@ -0,0 +1,427 @@
+package goff
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//
+// Transactions
+//
+
+const (
+	// AddTransaction is the type of a transaction that adds a player to a team
+	AddTransaction = "add"
+
+	// DropTransaction is the type of a transaction that drops a player from a
+	// team
+	DropTransaction = "drop"
+
+	// AddDropTransaction is the type of a transaction that adds a player to a
+	// team while dropping another
+	AddDropTransaction = "add/drop"
+
+	// PendingTradeTransaction is the type of a trade that has been proposed
+	// but not yet completed
+	PendingTradeTransaction = "pending_trade"
+
+	// TradeTransaction is the type of a completed trade
+	TradeTransaction = "trade"
+
+	// CommishTransaction is the type of a change made by the league's
+	// commissioner
+	CommishTransaction = "commish"
+
+	// WaiverTransaction is the type of a claim on a player on waivers that has
+	// not yet been processed
+	WaiverTransaction = "waiver"
+)
+
+// Actions that can be taken on a pending trade
+const (
+	acceptTradeAction      = "accept"
+	rejectTradeAction      = "reject"
+	voteAgainstTradeAction = "vote_against"
+)
+
+// A Transaction is a change to the players on one or more teams in a league.
+type Transaction struct {
+	TransactionKey    string   `xml:"transaction_key"`
+	TransactionID     uint64   `xml:"transaction_id"`
+	Type              string   `xml:"type"`
+	Status            string   `xml:"status"`
+	Timestamp         int64    `xml:"timestamp"`
+	TraderTeamKey     string   `xml:"trader_team_key"`
+	TraderTeamName    string   `xml:"trader_team_name"`
+	TradeeTeamKey     string   `xml:"tradee_team_key"`
+	TradeeTeamName    string   `xml:"tradee_team_name"`
+	TradeProposedTime int64    `xml:"trade_proposed_time"`
+	TradeNote         string   `xml:"trade_note"`
+	FaabBid           int      `xml:"faab_bid"`
+	Players           []Player `xml:"players>player"`
+}
+
+// TransactionFilter limits the transactions returned by GetTransactions. The
+// zero value returns all of a league's recent transactions.
+type TransactionFilter struct {
+	// Types of transactions to return, such as AddTransaction or
+	// TradeTransaction. All completed transactions are returned when empty.
+	// WaiverTransaction and PendingTradeTransaction require a TeamKey.
+	Types []string
+	// Only return transactions involving the given team, if not empty
+	TeamKey string
+	// Maximum number of transactions to return, if positive
+	Count int
+}
+
+// Time returns when the transaction was made.
+func (t *Transaction) Time() time.Time {
+	return time.Unix(t.Timestamp, 0)
+}
+
+// TradeProposal describes the players exchanged by a trade between two teams.
+type TradeProposal struct {
+	// Team proposing the trade
+	TraderTeamKey string
+	// Team the trade is proposed to
+	TradeeTeamKey string
+	// Players sent from the trader's team to the tradee's team
+	TraderPlayerKeys []string
+	// Players sent from the tradee's team to the trader's team
+	TradeePlayerKeys []string
+	// Optional message sent to the tradee
+	Note string
+}
+
+// TransactionData describes how a single player was moved by a Transaction.
+type TransactionData struct {
+	Type                string `xml:"type"`
+	SourceType          string `xml:"source_type"`
+	SourceTeamKey       string `xml:"source_team_key"`
+	SourceTeamName      string `xml:"source_team_name"`
+	DestinationType     string `xml:"destination_type"`
+	DestinationTeamKey  string `xml:"destination_team_key"`
+	DestinationTeamName string `xml:"destination_team_name"`
+}
+
+// transactionRequest is the body of a request to create a transaction.
+type transactionRequest struct {
+	XMLName     xml.Name          `xml:"fantasy_content"`
+	Transaction transactionChange `xml:"transaction"`
+}
+
+// transactionChange is a new transaction moving one or more players.
+type transactionChange struct {
+	TransactionKey string              `xml:"transaction_key,omitempty"`
+	Type           string              `xml:"type"`
+	Action         string              `xml:"action,omitempty"`
+	TraderTeamKey  string              `xml:"trader_team_key,omitempty"`
+	TradeeTeamKey  string              `xml:"tradee_team_key,omitempty"`
+	VoterTeamKey   string              `xml:"voter_team_key,omitempty"`
+	TradeNote      string              `xml:"trade_note,omitempty"`
+	Player         *transactionPlayer  `xml:"player,omitempty"`
+	Players        []transactionPlayer `xml:"players>player,omitempty"`
+}
+
+// transactionPlayer moves a single player as part of a new transaction.
+type transactionPlayer struct {
+	PlayerKey       string                  `xml:"player_key"`
+	TransactionData transactionPlayerChange `xml:"transaction_data"`
+}
+
+// transactionPlayerChange describes where a player is moved by a new
+// transaction.
+type transactionPlayerChange struct {
+	Type               string `xml:"type"`
+	SourceTeamKey      string `xml:"source_team_key,omitempty"`
+	DestinationTeamKey string `xml:"destination_team_key,omitempty"`
+}
+
+// GetTransactions returns the transactions made in the given league, most
+// recent first, that match the given filter.
+func (c *Client) GetTransactions(leagueKey string, filter TransactionFilter) ([]Transaction, error) {
+	return c.GetTransactionsContext(context.Background(), leagueKey, filter)
+}
+
+// GetTransactionsContext returns the transactions made in the given league
+// that match the given filter using the given context for the API request.
+func (c *Client) GetTransactionsContext(ctx context.Context, leagueKey string, filter TransactionFilter) ([]Transaction, error) {
+	url := fmt.Sprintf("%s/league/%s/transactions", c.baseURL(), leagueKey)
+	if len(filter.Types) > 0 {
+		url += ";types=" + strings.Join(filter.Types, ",")
+	}
+	if filter.TeamKey != "" {
+		url += ";team_key=" + filter.TeamKey
+	}
+	if filter.Count > 0 {
+		url += fmt.Sprintf(";count=%d", filter.Count)
+	}
+
+	content, err := c.GetFantasyContentContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return content.League.Transactions, nil
+}
+
+// AddPlayer adds the player to the given team in the given league. Depending
+// on the league's settings the returned transaction may be pending until the
+// player clears waivers.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) AddPlayer(leagueKey string, teamKey string, playerKey string) (*Transaction, error) {
+	return c.AddPlayerContext(context.Background(), leagueKey, teamKey, playerKey)
+}
+
+// AddPlayerContext adds the player to the given team in the given league using
+// the given context for the API request.
+func (c *Client) AddPlayerContext(ctx context.Context, leagueKey string, teamKey string, playerKey string) (*Transaction, error) {
+	add := addPlayer(teamKey, playerKey)
+	return c.sendTransaction(ctx, leagueKey, transactionChange{
+		Type:   AddTransaction,
+		Player: &add,
+	})
+}
+
+// DropPlayer drops the player from the given team in the given league.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) DropPlayer(leagueKey string, teamKey string, playerKey string) (*Transaction, error) {
+	return c.DropPlayerContext(context.Background(), leagueKey, teamKey, playerKey)
+}
+
+// DropPlayerContext drops the player from the given team in the given league
+// using the given context for the API request.
+func (c *Client) DropPlayerContext(ctx context.Context, leagueKey string, teamKey string, playerKey string) (*Transaction, error) {
+	drop := dropPlayer(teamKey, playerKey)
+	return c.sendTransaction(ctx, leagueKey, transactionChange{
+		Type:   DropTransaction,
+		Player: &drop,
+	})
+}
+
+// AddDropPlayer adds a player to the given team in the given league while
+// dropping another player from the team in a single transaction.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) AddDropPlayer(leagueKey string, teamKey string, addPlayerKey string, dropPlayerKey string) (*Transaction, error) {
+	return c.AddDropPlayerContext(
+		context.Background(),
+		leagueKey,
+		teamKey,
+		addPlayerKey,
+		dropPlayerKey)
+}
+
+// AddDropPlayerContext adds a player to the given team in the given league
+// while dropping another player from the team using the given context for the
+// API request.
+func (c *Client) AddDropPlayerContext(ctx context.Context, leagueKey string, teamKey string, addPlayerKey string, dropPlayerKey string) (*Transaction, error) {
+	return c.sendTransaction(ctx, leagueKey, transactionChange{
+		Type: AddDropTransaction,
+		Players: []transactionPlayer{
+			addPlayer(teamKey, addPlayerKey),
+			dropPlayer(teamKey, dropPlayerKey),
+		},
+	})
+}
+
+// sendTransaction creates the transaction in the given league and returns the
+// transaction created by Yahoo.
+func (c *Client) sendTransaction(ctx context.Context, leagueKey string, change transactionChange) (*Transaction, error) {
+	body, err := marshalRequest(&transactionRequest{Transaction: change})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := c.SendFantasyContentContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/league/%s/transactions", c.baseURL(), leagueKey),
+		body)
+	if err != nil {
+		return nil, err
+	}
+	return &content.Transaction, nil
+}
+
+// ProposeTrade proposes a trade between two teams in the given league. The
+// returned transaction is pending until the tradee accepts or rejects it.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) ProposeTrade(leagueKey string, proposal TradeProposal) (*Transaction, error) {
+	return c.ProposeTradeContext(context.Background(), leagueKey, proposal)
+}
+
+// ProposeTradeContext proposes a trade between two teams in the given league
+// using the given context for the API request.
+func (c *Client) ProposeTradeContext(ctx context.Context, leagueKey string, proposal TradeProposal) (*Transaction, error) {
+	if len(proposal.TraderPlayerKeys) == 0 && len(proposal.TradeePlayerKeys) == 0 {
+		return nil, fmt.Errorf("no players given for trade between "+
+			"trader=%s, tradee=%s",
+			proposal.TraderTeamKey,
+			proposal.TradeeTeamKey)
+	}
+
+	players := make(
+		[]transactionPlayer,
+		0,
+		len(proposal.TraderPlayerKeys)+len(proposal.TradeePlayerKeys))
+	for _, playerKey := range proposal.TraderPlayerKeys {
+		players = append(players, tradePlayer(
+			playerKey,
+			proposal.TraderTeamKey,
+			proposal.TradeeTeamKey))
+	}
+	for _, playerKey := range proposal.TradeePlayerKeys {
+		players = append(players, tradePlayer(
+			playerKey,
+			proposal.TradeeTeamKey,
+			proposal.TraderTeamKey))
+	}
+
+	return c.sendTransaction(ctx, leagueKey, transactionChange{
+		Type:          PendingTradeTransaction,
+		TraderTeamKey: proposal.TraderTeamKey,
+		TradeeTeamKey: proposal.TradeeTeamKey,
+		TradeNote:     proposal.Note,
+		Players:       players,
+	})
+}
+
+// AcceptTrade accepts the pending trade with the given transaction key. This
+// must be done by the manager of the tradee's team. An optional note can be
+// sent to the trader.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) AcceptTrade(transactionKey string, note string) error {
+	return c.AcceptTradeContext(context.Background(), transactionKey, note)
+}
+
+// AcceptTradeContext accepts the pending trade with the given transaction key
+// using the given context for the API request.
+func (c *Client) AcceptTradeContext(ctx context.Context, transactionKey string, note string) error {
+	return c.updateTrade(ctx, transactionKey, transactionChange{
+		Action:    acceptTradeAction,
+		TradeNote: note,
+	})
+}
+
+// RejectTrade rejects the pending trade with the given transaction key. This
+// must be done by the manager of the tradee's team. An optional note can be
+// sent to the trader.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) RejectTrade(transactionKey string, note string) error {
+	return c.RejectTradeContext(context.Background(), transactionKey, note)
+}
+
+// RejectTradeContext rejects the pending trade with the given transaction key
+// using the given context for the API request.
+func (c *Client) RejectTradeContext(ctx context.Context, transactionKey string, note string) error {
+	return c.updateTrade(ctx, transactionKey, transactionChange{
+		Action:    rejectTradeAction,
+		TradeNote: note,
+	})
+}
+
+// VoteTrade votes against the accepted trade with the given transaction key
+// on behalf of the given team, in leagues where managers can vote to veto
+// trades.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) VoteTrade(transactionKey string, voterTeamKey string) error {
+	return c.VoteTradeContext(context.Background(), transactionKey, voterTeamKey)
+}
+
+// VoteTradeContext votes against the accepted trade with the given
+// transaction key on behalf of the given team using the given context for the
+// API request.
+func (c *Client) VoteTradeContext(ctx context.Context, transactionKey string, voterTeamKey string) error {
+	return c.updateTrade(ctx, transactionKey, transactionChange{
+		Action:       voteAgainstTradeAction,
+		VoterTeamKey: voterTeamKey,
+	})
+}
+
+// CancelTrade cancels the pending trade with the given transaction key. This
+// must be done by the manager of the trader's team before the trade is
+// accepted.
+//
+// The client must be authorized with ReadWriteScope.
+func (c *Client) CancelTrade(transactionKey string) error {
+	return c.CancelTradeContext(context.Background(), transactionKey)
+}
+
+// CancelTradeContext cancels the pending trade with the given transaction key
+// using the given context for the API request.
+func (c *Client) CancelTradeContext(ctx context.Context, transactionKey string) error {
+	_, err := c.SendFantasyContentContext(
+		ctx,
+		http.MethodDelete,
+		c.transactionURL(transactionKey),
+		nil)
+	return err
+}
+
+// updateTrade takes an action on the pending trade with the given
+// transaction key.
+func (c *Client) updateTrade(ctx context.Context, transactionKey string, change transactionChange) error {
+	change.TransactionKey = transactionKey
+	change.Type = PendingTradeTransaction
+
+	body, err := marshalRequest(&transactionRequest{Transaction: change})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.SendFantasyContentContext(
+		ctx,
+		http.MethodPut,
+		c.transactionURL(transactionKey),
+		body)
+	return err
+}
+
+// transactionURL returns the URL of the transaction with the given key.
+func (c *Client) transactionURL(transactionKey string) string {
+	return fmt.Sprintf("%s/transaction/%s", c.baseURL(), transactionKey)
+}
+
+// tradePlayer moves a player from one team to another as part of a trade.
+func tradePlayer(playerKey string, sourceTeamKey string, destinationTeamKey string) transactionPlayer {
+	return transactionPlayer{
+		PlayerKey: playerKey,
+		TransactionData: transactionPlayerChange{
+			Type:               PendingTradeTransaction,
+			SourceTeamKey:      sourceTeamKey,
+			DestinationTeamKey: destinationTeamKey,
+		},
+	}
+}
+
+// addPlayer moves a player to the given team.
+func addPlayer(teamKey string, playerKey string) transactionPlayer {
+	return transactionPlayer{
+		PlayerKey: playerKey,
+		TransactionData: transactionPlayerChange{
+			Type:               AddTransaction,
+			DestinationTeamKey: teamKey,
+		},
+	}
+}
+
+// dropPlayer removes a player from the given team.
+func dropPlayer(teamKey string, playerKey string) transactionPlayer {
+	return transactionPlayer{
+		PlayerKey: playerKey,
+		TransactionData: transactionPlayerChange{
+			Type:          DropTransaction,
+			SourceTeamKey: teamKey,
+		},
+	}
+}
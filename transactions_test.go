@@ -0,0 +1,622 @@
+package goff
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+//
+// Test GetTransactions
+//
+
+func TestGetTransactions(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{
+			League: League{
+				Transactions: []Transaction{expectedTransaction},
+			},
+		},
+		err: nil,
+	}
+	client := &Client{Provider: provider}
+
+	transactions, err := client.GetTransactions("223.l.431", TransactionFilter{
+		Types:   []string{AddTransaction, TradeTransaction},
+		TeamKey: "223.l.431.t.1",
+		Count:   5,
+	})
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	if len(transactions) != 1 {
+		t.Fatalf("Unexpected transactions returned: %+v", transactions)
+	}
+	assertStringEquals(
+		t,
+		expectedTransaction.TransactionKey,
+		transactions[0].TransactionKey)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/223.l.431/transactions;types=add,trade;"+
+			"team_key=223.l.431.t.1;count=5",
+		provider.lastGetURL)
+}
+
+func TestGetTransactionsNoFilter(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	transactions, err := client.GetTransactions("223.l.431", TransactionFilter{})
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	if len(transactions) != 0 {
+		t.Fatalf("Unexpected transactions returned: %+v", transactions)
+	}
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/223.l.431/transactions",
+		provider.lastGetURL)
+}
+
+func TestGetTransactionsError(t *testing.T) {
+	client := mockClient(nil, errors.New("error"))
+
+	_, err := client.GetTransactions("223.l.431", TransactionFilter{})
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+}
+
+func TestXMLContentProviderGetLeagueTransactions(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{
+			Response: mockResponse(leagueTransactionsXMLContent),
+		},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	transactions := content.League.Transactions
+	if len(transactions) != 2 {
+		t.Fatalf("Unexpected transactions parsed: %+v", transactions)
+	}
+
+	claim := transactions[0]
+	assertStringEquals(t, "223.l.431.tr.27", claim.TransactionKey)
+	assertStringEquals(t, AddDropTransaction, claim.Type)
+	assertStringEquals(t, "successful", claim.Status)
+	assertIntEquals(t, 12, claim.FaabBid)
+	assertIntEquals(t, 2011, claim.Time().Year())
+	if len(claim.Players) != 2 {
+		t.Fatalf("Unexpected players parsed: %+v", claim.Players)
+	}
+
+	add := claim.Players[0].TransactionData
+	assertStringEquals(t, AddTransaction, add.Type)
+	assertStringEquals(t, "waivers", add.SourceType)
+	assertStringEquals(t, "team", add.DestinationType)
+	assertStringEquals(t, "223.l.431.t.2", add.DestinationTeamKey)
+	assertStringEquals(t, "Team Two", add.DestinationTeamName)
+
+	drop := claim.Players[1].TransactionData
+	assertStringEquals(t, DropTransaction, drop.Type)
+	assertStringEquals(t, "team", drop.SourceType)
+	assertStringEquals(t, "223.l.431.t.2", drop.SourceTeamKey)
+	assertStringEquals(t, "Team Two", drop.SourceTeamName)
+	assertStringEquals(t, "waivers", drop.DestinationType)
+
+	trade := transactions[1]
+	assertStringEquals(t, TradeTransaction, trade.Type)
+	assertStringEquals(t, "223.l.431.t.1", trade.TraderTeamKey)
+	assertStringEquals(t, "223.l.431.t.2", trade.TradeeTeamKey)
+	assertIntEquals(t, 0, trade.FaabBid)
+}
+
+//
+// Test AddPlayer, DropPlayer, and AddDropPlayer
+//
+
+func TestAddPlayer(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{Transaction: expectedTransaction},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	transaction, err := client.AddPlayer("223.l.431", "223.l.431.t.1", "223.p.8261")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertStringEquals(t, expectedTransaction.TransactionKey, transaction.TransactionKey)
+	assertStringEquals(t, http.MethodPost, provider.lastSendMethod)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/223.l.431/transactions",
+		provider.lastGetURL)
+
+	request := parseTransactionRequest(t, provider.lastSendBody)
+	assertStringEquals(t, AddTransaction, request.Type)
+	if request.Player == nil || len(request.Players) != 0 {
+		t.Fatalf("Unexpected players sent: %+v", request)
+	}
+	assertStringEquals(t, "223.p.8261", request.Player.PlayerKey)
+	assertStringEquals(t, AddTransaction, request.Player.TransactionData.Type)
+	assertStringEquals(
+		t,
+		"223.l.431.t.1",
+		request.Player.TransactionData.DestinationTeamKey)
+	assertStringEquals(t, "", request.Player.TransactionData.SourceTeamKey)
+}
+
+func TestDropPlayer(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{Transaction: expectedTransaction},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	_, err := client.DropPlayer("223.l.431", "223.l.431.t.1", "223.p.8261")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	request := parseTransactionRequest(t, provider.lastSendBody)
+	assertStringEquals(t, DropTransaction, request.Type)
+	if request.Player == nil {
+		t.Fatalf("No player sent: %+v", request)
+	}
+	assertStringEquals(t, "223.p.8261", request.Player.PlayerKey)
+	assertStringEquals(t, DropTransaction, request.Player.TransactionData.Type)
+	assertStringEquals(
+		t,
+		"223.l.431.t.1",
+		request.Player.TransactionData.SourceTeamKey)
+}
+
+func TestAddDropPlayer(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{Transaction: expectedTransaction},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	_, err := client.AddDropPlayerContext(
+		context.Background(),
+		"223.l.431",
+		"223.l.431.t.1",
+		"223.p.8261",
+		"223.p.1234")
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	request := parseTransactionRequest(t, provider.lastSendBody)
+	assertStringEquals(t, AddDropTransaction, request.Type)
+	if request.Player != nil || len(request.Players) != 2 {
+		t.Fatalf("Unexpected players sent: %+v", request)
+	}
+
+	add := request.Players[0]
+	assertStringEquals(t, "223.p.8261", add.PlayerKey)
+	assertStringEquals(t, AddTransaction, add.TransactionData.Type)
+	assertStringEquals(t, "223.l.431.t.1", add.TransactionData.DestinationTeamKey)
+
+	drop := request.Players[1]
+	assertStringEquals(t, "223.p.1234", drop.PlayerKey)
+	assertStringEquals(t, DropTransaction, drop.TransactionData.Type)
+	assertStringEquals(t, "223.l.431.t.1", drop.TransactionData.SourceTeamKey)
+}
+
+func TestAddPlayerError(t *testing.T) {
+	client := mockClient(nil, &APIError{StatusCode: http.StatusBadRequest})
+
+	_, err := client.AddPlayer("223.l.431", "223.l.431.t.1", "223.p.8261")
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			ErrBadRequest,
+			err)
+	}
+}
+
+func TestXMLContentProviderGetTransaction(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(transactionXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Send(
+		context.Background(),
+		http.MethodPost,
+		"http://example.com",
+		[]byte("<fantasy_content/>"))
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	transaction := content.Transaction
+	assertStringEquals(t, expectedTransaction.TransactionKey, transaction.TransactionKey)
+	assertUintEquals(t, expectedTransaction.TransactionID, transaction.TransactionID)
+	assertStringEquals(t, AddDropTransaction, transaction.Type)
+	assertStringEquals(t, "successful", transaction.Status)
+	if len(transaction.Players) != 2 {
+		t.Fatalf("Unexpected players parsed: %+v", transaction.Players)
+	}
+
+	data := transaction.Players[0].TransactionData
+	assertStringEquals(t, AddTransaction, data.Type)
+	assertStringEquals(t, "freeagents", data.SourceType)
+	assertStringEquals(t, "team", data.DestinationType)
+	assertStringEquals(t, "223.l.431.t.1", data.DestinationTeamKey)
+
+	data = transaction.Players[1].TransactionData
+	assertStringEquals(t, DropTransaction, data.Type)
+	assertStringEquals(t, "223.l.431.t.1", data.SourceTeamKey)
+	assertStringEquals(t, "waivers", data.DestinationType)
+}
+
+//
+// Test trades
+//
+
+func TestProposeTrade(t *testing.T) {
+	provider := &mockedContentProvider{
+		content: &FantasyContent{Transaction: expectedTrade},
+		err:     nil,
+	}
+	client := &Client{Provider: provider}
+
+	transaction, err := client.ProposeTrade("223.l.431", TradeProposal{
+		TraderTeamKey:    "223.l.431.t.1",
+		TradeeTeamKey:    "223.l.431.t.2",
+		TraderPlayerKeys: []string{"223.p.8261"},
+		TradeePlayerKeys: []string{"223.p.1234", "223.p.5678"},
+		Note:             "Let's trade",
+	})
+	if err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertStringEquals(t, expectedTrade.TransactionKey, transaction.TransactionKey)
+	assertStringEquals(t, http.MethodPost, provider.lastSendMethod)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/league/223.l.431/transactions",
+		provider.lastGetURL)
+
+	request := parseTransactionRequest(t, provider.lastSendBody)
+	assertStringEquals(t, PendingTradeTransaction, request.Type)
+	assertStringEquals(t, "223.l.431.t.1", request.TraderTeamKey)
+	assertStringEquals(t, "223.l.431.t.2", request.TradeeTeamKey)
+	assertStringEquals(t, "Let's trade", request.TradeNote)
+	if len(request.Players) != 3 {
+		t.Fatalf("Unexpected players sent: %+v", request.Players)
+	}
+
+	expected := []transactionPlayer{
+		tradePlayer("223.p.8261", "223.l.431.t.1", "223.l.431.t.2"),
+		tradePlayer("223.p.1234", "223.l.431.t.2", "223.l.431.t.1"),
+		tradePlayer("223.p.5678", "223.l.431.t.2", "223.l.431.t.1"),
+	}
+	for i, player := range request.Players {
+		if player != expected[i] {
+			t.Fatalf("Unexpected player sent\n\texpected: %+v\n\tactual: %+v",
+				expected[i],
+				player)
+		}
+	}
+}
+
+func TestProposeTradeNoPlayers(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	_, err := client.ProposeTrade("223.l.431", TradeProposal{
+		TraderTeamKey: "223.l.431.t.1",
+		TradeeTeamKey: "223.l.431.t.2",
+	})
+	if err == nil {
+		t.Fatal("Client did not return error")
+	}
+	assertIntEquals(t, 0, provider.count)
+}
+
+func TestUpdateTrade(t *testing.T) {
+	tests := map[string]struct {
+		update       func(client *Client) error
+		action       string
+		note         string
+		voterTeamKey string
+	}{
+		"AcceptTrade": {
+			update: func(client *Client) error {
+				return client.AcceptTrade(expectedTrade.TransactionKey, "Deal")
+			},
+			action: "accept",
+			note:   "Deal",
+		},
+		"RejectTrade": {
+			update: func(client *Client) error {
+				return client.RejectTrade(expectedTrade.TransactionKey, "No thanks")
+			},
+			action: "reject",
+			note:   "No thanks",
+		},
+		"VoteTrade": {
+			update: func(client *Client) error {
+				return client.VoteTrade(expectedTrade.TransactionKey, "223.l.431.t.3")
+			},
+			action:       "vote_against",
+			voterTeamKey: "223.l.431.t.3",
+		},
+	}
+
+	for name, test := range tests {
+		provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+		client := &Client{Provider: provider}
+
+		if err := test.update(client); err != nil {
+			t.Fatalf("%s returned unexpected error: %s", name, err)
+		}
+
+		assertStringEquals(t, http.MethodPut, provider.lastSendMethod)
+		assertStringEquals(
+			t,
+			YahooBaseURL+"/transaction/"+expectedTrade.TransactionKey,
+			provider.lastGetURL)
+
+		request := parseTransactionRequest(t, provider.lastSendBody)
+		assertStringEquals(t, expectedTrade.TransactionKey, request.TransactionKey)
+		assertStringEquals(t, PendingTradeTransaction, request.Type)
+		assertStringEquals(t, test.action, request.Action)
+		assertStringEquals(t, test.note, request.TradeNote)
+		assertStringEquals(t, test.voterTeamKey, request.VoterTeamKey)
+	}
+}
+
+func TestCancelTrade(t *testing.T) {
+	provider := &mockedContentProvider{content: &FantasyContent{}, err: nil}
+	client := &Client{Provider: provider}
+
+	if err := client.CancelTrade(expectedTrade.TransactionKey); err != nil {
+		t.Fatalf("Client returned unexpected error: %s", err)
+	}
+
+	assertStringEquals(t, http.MethodDelete, provider.lastSendMethod)
+	assertStringEquals(
+		t,
+		YahooBaseURL+"/transaction/"+expectedTrade.TransactionKey,
+		provider.lastGetURL)
+	if provider.lastSendBody != nil {
+		t.Fatalf("Unexpected body sent: %s", provider.lastSendBody)
+	}
+}
+
+func TestAcceptTradeError(t *testing.T) {
+	client := mockClient(nil, &APIError{StatusCode: http.StatusBadRequest})
+
+	err := client.AcceptTrade(expectedTrade.TransactionKey, "")
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("Unexpected error returned\n\texpected: %s\n\tactual: %v",
+			ErrBadRequest,
+			err)
+	}
+}
+
+func TestXMLContentProviderGetTrade(t *testing.T) {
+	client := &countingHTTPApiClient{
+		client: &mockHTTPClient{Response: mockResponse(tradeXMLContent)},
+	}
+
+	provider := &xmlContentProvider{client: client}
+	content, err := provider.Get(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error returned: %s", err)
+	}
+
+	trade := content.Transaction
+	assertStringEquals(t, expectedTrade.TransactionKey, trade.TransactionKey)
+	assertStringEquals(t, PendingTradeTransaction, trade.Type)
+	assertStringEquals(t, "proposed", trade.Status)
+	assertStringEquals(t, "223.l.431.t.1", trade.TraderTeamKey)
+	assertStringEquals(t, "Trader Team", trade.TraderTeamName)
+	assertStringEquals(t, "223.l.431.t.2", trade.TradeeTeamKey)
+	assertStringEquals(t, "Tradee Team", trade.TradeeTeamName)
+	assertStringEquals(t, "Let's trade", trade.TradeNote)
+	if trade.TradeProposedTime != 1310694660 {
+		t.Fatalf("Unexpected trade proposed time: %d", trade.TradeProposedTime)
+	}
+	if len(trade.Players) != 2 {
+		t.Fatalf("Unexpected players parsed: %+v", trade.Players)
+	}
+
+	data := trade.Players[1].TransactionData
+	assertStringEquals(t, PendingTradeTransaction, data.Type)
+	assertStringEquals(t, "223.l.431.t.2", data.SourceTeamKey)
+	assertStringEquals(t, "223.l.431.t.1", data.DestinationTeamKey)
+}
+
+// parseTransactionRequest parses the body of a request to create a
+// transaction.
+func parseTransactionRequest(t *testing.T, body []byte) transactionChange {
+	var request transactionRequest
+	if err := xml.Unmarshal(body, &request); err != nil {
+		t.Fatalf("Unable to parse request body: %s\n\tbody: %s", err, body)
+	}
+	return request.Transaction
+}
+
+var expectedTransaction = Transaction{
+	TransactionKey: "223.l.431.tr.26",
+	TransactionID:  26,
+	Type:           AddDropTransaction,
+	Status:         "successful",
+}
+
+var transactionXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431/transactions" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="92.169046401978ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <transaction>
+    <transaction_key>223.l.431.tr.26</transaction_key>
+    <transaction_id>26</transaction_id>
+    <type>add/drop</type>
+    <status>successful</status>
+    <timestamp>1310694660</timestamp>
+    <players count="2">
+      <player>
+        <player_key>223.p.8261</player_key>
+        <player_id>8261</player_id>
+        <name>
+          <full>Adrian Peterson</full>
+          <first>Adrian</first>
+          <last>Peterson</last>
+        </name>
+        <transaction_data>
+          <type>add</type>
+          <source_type>freeagents</source_type>
+          <destination_type>team</destination_type>
+          <destination_team_key>223.l.431.t.1</destination_team_key>
+        </transaction_data>
+      </player>
+      <player>
+        <player_key>223.p.1234</player_key>
+        <player_id>1234</player_id>
+        <name>
+          <full>Firstname Lastname</full>
+          <first>Firstname</first>
+          <last>Lastname</last>
+        </name>
+        <transaction_data>
+          <type>drop</type>
+          <source_type>team</source_type>
+          <source_team_key>223.l.431.t.1</source_team_key>
+          <destination_type>waivers</destination_type>
+        </transaction_data>
+      </player>
+    </players>
+  </transaction>
+</fantasy_content>`
+
+var expectedTrade = Transaction{
+	TransactionKey: "223.l.431.pt.1",
+	TransactionID:  1,
+	Type:           PendingTradeTransaction,
+	Status:         "proposed",
+}
+
+var tradeXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/transaction/223.l.431.pt.1" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="40.618896484375ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <transaction>
+    <transaction_key>223.l.431.pt.1</transaction_key>
+    <transaction_id>1</transaction_id>
+    <type>pending_trade</type>
+    <status>proposed</status>
+    <trader_team_key>223.l.431.t.1</trader_team_key>
+    <trader_team_name>Trader Team</trader_team_name>
+    <tradee_team_key>223.l.431.t.2</tradee_team_key>
+    <tradee_team_name>Tradee Team</tradee_team_name>
+    <trade_proposed_time>1310694660</trade_proposed_time>
+    <trade_note>Let's trade</trade_note>
+    <players count="2">
+      <player>
+        <player_key>223.p.8261</player_key>
+        <player_id>8261</player_id>
+        <transaction_data>
+          <type>pending_trade</type>
+          <source_type>team</source_type>
+          <source_team_key>223.l.431.t.1</source_team_key>
+          <destination_type>team</destination_type>
+          <destination_team_key>223.l.431.t.2</destination_team_key>
+        </transaction_data>
+      </player>
+      <player>
+        <player_key>223.p.1234</player_key>
+        <player_id>1234</player_id>
+        <transaction_data>
+          <type>pending_trade</type>
+          <source_type>team</source_type>
+          <source_team_key>223.l.431.t.2</source_team_key>
+          <destination_type>team</destination_type>
+          <destination_team_key>223.l.431.t.1</destination_team_key>
+        </transaction_data>
+      </player>
+    </players>
+  </transaction>
+</fantasy_content>`
+
+var leagueTransactionsXMLContent = `
+<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xml:lang="en-US" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/223.l.431/transactions" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" time="97.240924835205ms" copyright="Data provided by Yahoo! and STATS, LLC" refresh_rate="60" xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <league>
+    <league_key>223.l.431</league_key>
+    <league_id>431</league_id>
+    <name>League Name</name>
+    <transactions count="2">
+      <transaction>
+        <transaction_key>223.l.431.tr.27</transaction_key>
+        <transaction_id>27</transaction_id>
+        <type>add/drop</type>
+        <status>successful</status>
+        <timestamp>1310694660</timestamp>
+        <faab_bid>12</faab_bid>
+        <players count="2">
+          <player>
+            <player_key>223.p.8261</player_key>
+            <player_id>8261</player_id>
+            <transaction_data>
+              <type>add</type>
+              <source_type>waivers</source_type>
+              <destination_type>team</destination_type>
+              <destination_team_key>223.l.431.t.2</destination_team_key>
+              <destination_team_name>Team Two</destination_team_name>
+            </transaction_data>
+          </player>
+          <player>
+            <player_key>223.p.1234</player_key>
+            <player_id>1234</player_id>
+            <transaction_data>
+              <type>drop</type>
+              <source_type>team</source_type>
+              <source_team_key>223.l.431.t.2</source_team_key>
+              <source_team_name>Team Two</source_team_name>
+              <destination_type>waivers</destination_type>
+            </transaction_data>
+          </player>
+        </players>
+      </transaction>
+      <transaction>
+        <transaction_key>223.l.431.tr.26</transaction_key>
+        <transaction_id>26</transaction_id>
+        <type>trade</type>
+        <status>successful</status>
+        <timestamp>1310600000</timestamp>
+        <trader_team_key>223.l.431.t.1</trader_team_key>
+        <tradee_team_key>223.l.431.t.2</tradee_team_key>
+        <players count="1">
+          <player>
+            <player_key>223.p.5678</player_key>
+            <player_id>5678</player_id>
+            <transaction_data>
+              <type>trade</type>
+              <source_type>team</source_type>
+              <source_team_key>223.l.431.t.1</source_team_key>
+              <destination_type>team</destination_type>
+              <destination_team_key>223.l.431.t.2</destination_team_key>
+            </transaction_data>
+          </player>
+        </players>
+      </transaction>
+    </transactions>
+  </league>
+</fantasy_content>`